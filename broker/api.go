@@ -0,0 +1,366 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"uk.ac.bris.cs/gameoflife/rle"
+	"uk.ac.bris.cs/gameoflife/rule"
+	"uk.ac.bris.cs/gameoflife/stubs"
+	"uk.ac.bris.cs/gameoflife/util"
+)
+
+// Formats of the worlds sent to and from the JSON API
+const (
+	formatRLE  = "rle"  // a pattern in RLE, which keeps the states of rules with more than two states
+	formatBits = "bits" // rows of bits packed like util.BitArray, each starting on a new byte, in base64
+)
+
+// apiRun is the body of a request to start a run. The world is given as RLE or bits, with a pattern placed at the
+// top left of a world that is the size of the pattern when Width and Height are left out, grown to a whole number of
+// bytes wide for Life and to a row for each worker. The rule of the pattern is used when Rule is left out
+type apiRun struct {
+	Width    int    `json:"width"`
+	Height   int    `json:"height"`
+	Turns    int    `json:"turns"`
+	Rule     string `json:"rule"`
+	Boundary string `json:"boundary"`
+	Engine   string `json:"engine"`
+	Threads  int    `json:"threads"`
+	RLE      string `json:"rle"`
+	Bits     string `json:"bits"`
+	Resume   bool   `json:"resume"` // carry on from the current world instead of a new one
+	Wait     bool   `json:"wait"`   // answer with the final world once the turns are done, instead of straight away
+	Format   string `json:"format"` // the format of the final world, rle if it is left out
+}
+
+// apiWorld is a world sent back by the JSON API, in RLE or bits
+type apiWorld struct {
+	Turn   int    `json:"turn"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+	Alive  int    `json:"alive"`
+	Rule   string `json:"rule"`
+	RLE    string `json:"rle,omitempty"`
+	Bits   string `json:"bits,omitempty"`
+}
+
+// apiCount is the number of alive cells on a turn
+type apiCount struct {
+	Turn  int `json:"turn"`
+	Alive int `json:"alive"`
+}
+
+// apiState is whether the broker is carrying out turns, and whether it is paused
+type apiState struct {
+	Turn    int  `json:"turn"`
+	Running bool `json:"running"`
+	Paused  bool `json:"paused"`
+}
+
+// apiError is the body of every response that is not a success
+type apiError struct {
+	Error string `json:"error"`
+}
+
+// writeJSON sends v as the body of a response with the given status
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		fmt.Println(err)
+	}
+}
+
+// writeError sends an error as JSON
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, apiError{Error: err.Error()})
+}
+
+// method returns a handler that only accepts one HTTP method
+func method(name string, handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != name {
+			writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("use %s", name))
+			return
+		}
+		handler(w, r)
+	}
+}
+
+// state returns whether the broker is running and paused
+func (g *GameOfLifeOperations) state() apiState {
+	mutex.Lock()
+	defer mutex.Unlock()
+	return apiState{Turn: g.CompletedTurns, Running: g.running, Paused: g.pause}
+}
+
+// snapshot returns the current world along with the rule it is being run with, both taken under the mutex so that
+// they belong to the same run
+func (g *GameOfLifeOperations) snapshot() (stubs.CurrentWorldResponse, string) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	return stubs.CurrentWorldResponse{World: g.currentWorld(), States: g.States, CompletedTurns: g.CompletedTurns}, g.rule
+}
+
+// newWorld builds the world of a run request, in World for Life and in States for any other rule, returning its width
+func (req apiRun) newWorld(r rule.Rule) (width int, world []util.BitArray, states []util.StateArray, err error) {
+	var pattern *rle.Pattern
+	var bits []byte
+	switch {
+	case req.RLE != "" && req.Bits != "":
+		return 0, nil, nil, errors.New("give the world as rle or bits, not both")
+	case req.RLE != "":
+		if pattern, err = rle.Parse(req.RLE); err != nil {
+			return 0, nil, nil, err
+		}
+		if int(pattern.MaxState()) >= r.States() {
+			return 0, nil, nil, fmt.Errorf("the pattern has state %d but %s only has %d states", pattern.MaxState(), r, r.States())
+		}
+	case req.Bits != "":
+		if bits, err = base64.StdEncoding.DecodeString(req.Bits); err != nil {
+			return 0, nil, nil, err
+		}
+		if len(bits) != (req.Width+7)/8*req.Height {
+			return 0, nil, nil, fmt.Errorf("%d bytes of bits do not fit a %dx%d world", len(bits), req.Width, req.Height)
+		}
+	default:
+		return 0, nil, nil, errors.New("give the world as rle or bits")
+	}
+	life := r.String() == rule.Life
+	if pattern != nil && req.Width == 0 && req.Height == 0 {
+		req.Width, req.Height = pattern.Width, pattern.Height
+		if life {
+			req.Width = (req.Width + 7) &^ 7
+		}
+		if req.Height < stubs.Threads {
+			req.Height = stubs.Threads
+		}
+	}
+	if req.Width <= 0 || req.Height < stubs.Threads || req.Width > rle.MaxSize || req.Height > rle.MaxSize {
+		return 0, nil, nil, fmt.Errorf("a world of %dx%d is not between 1x%d and %dx%d", req.Width, req.Height, stubs.Threads, rle.MaxSize, rle.MaxSize)
+	}
+	if life && req.Width%8 != 0 {
+		return 0, nil, nil, fmt.Errorf("the width of a Life world is stored in whole bytes, so %d should be a multiple of 8", req.Width)
+	}
+	if pattern != nil && (pattern.Width > req.Width || pattern.Height > req.Height) {
+		return 0, nil, nil, fmt.Errorf("a pattern of %dx%d does not fit a %dx%d world", pattern.Width, pattern.Height, req.Width, req.Height)
+	}
+
+	at := func(x, y int) uint8 {
+		if pattern != nil {
+			if x < pattern.Width && y < pattern.Height {
+				return pattern.At(x, y)
+			}
+			return 0
+		}
+		return bits[y*((req.Width+7)/8)+x/8] >> uint(x%8) & 1
+	}
+	for y := 0; y < req.Height; y++ {
+		if life {
+			row := util.NewBitArray((req.Width + 7) &^ 7)
+			for x := 0; x < req.Width; x++ {
+				row.SetBit(x, at(x, y) != 0)
+			}
+			world = append(world, row)
+			continue
+		}
+		row := util.NewStateArray(req.Width, util.BitsForStates(r.States()))
+		for x := 0; x < req.Width; x++ {
+			row.Set(x, at(x, y))
+		}
+		states = append(states, row)
+	}
+	return req.Width, world, states, nil
+}
+
+// encodeWorld returns a world in the given format, with states nil for Life
+func encodeWorld(turn int, ruleName string, world []util.BitArray, states []util.StateArray, format string) (apiWorld, error) {
+	out := apiWorld{Turn: turn, Rule: ruleName, Height: len(world)}
+	if states != nil {
+		out.Height = len(states)
+		if out.Height > 0 {
+			out.Width = states[0].Len()
+		}
+	} else if out.Height > 0 {
+		out.Width = world[0].Len()
+	}
+	at := func(x, y int) uint8 {
+		if states != nil {
+			return states[y].Get(x)
+		}
+		return world[y].GetBitToUint8(x)
+	}
+	pattern := &rle.Pattern{Width: out.Width, Height: out.Height, Rule: ruleName, States: make([][]uint8, out.Height)}
+	rowBytes := (out.Width + 7) / 8
+	bits := make([]byte, rowBytes*out.Height)
+	for y := 0; y < out.Height; y++ {
+		pattern.States[y] = make([]uint8, out.Width)
+		for x := 0; x < out.Width; x++ {
+			state := at(x, y)
+			pattern.States[y][x] = state
+			if state != 0 {
+				out.Alive++
+				bits[y*rowBytes+x/8] |= 1 << uint(x%8)
+			}
+		}
+	}
+	switch format {
+	case formatRLE, "":
+		out.RLE = pattern.Encode()
+	case formatBits:
+		out.Bits = base64.StdEncoding.EncodeToString(bits)
+	default:
+		return out, fmt.Errorf("unknown format %q, expected %s or %s", format, formatRLE, formatBits)
+	}
+	return out, nil
+}
+
+// apiRunWorld starts a run, the same as a controller calling RunGameOfLife
+func (g *GameOfLifeOperations) apiRunWorld(w http.ResponseWriter, r *http.Request) {
+	var req apiRun
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if req.Format != "" && req.Format != formatRLE && req.Format != formatBits {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("unknown format %q, expected %s or %s", req.Format, formatRLE, formatBits))
+		return
+	}
+	if req.Rule == "" && req.RLE != "" {
+		if pattern, err := rle.Parse(req.RLE); err == nil {
+			req.Rule = pattern.Rule
+		}
+	}
+	ruleOf, err := rule.Parse(req.Rule)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	boundary := util.Torus
+	if req.Boundary != "" {
+		if boundary, err = util.ParseBoundary(req.Boundary); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+	request := stubs.Request{Turns: req.Turns, Resume: req.Resume, Engine: req.Engine, Boundary: boundary, Rule: req.Rule, Threads: req.Threads}
+	if req.Resume {
+		world, ruleName := g.snapshot()
+		if world.World == nil && world.States == nil {
+			writeError(w, http.StatusConflict, errors.New("there is no world to resume"))
+			return
+		}
+		// a resumed run carries on with the rule of the current world, whatever the request names
+		if ruleOf, err = rule.Parse(ruleName); err != nil {
+			writeError(w, http.StatusConflict, err)
+			return
+		}
+		if world.States != nil {
+			request.ImageWidth, request.ImageHeight = world.States[0].Len(), len(world.States)
+		} else {
+			request.ImageWidth, request.ImageHeight = world.World[0].Len(), len(world.World)
+		}
+	} else {
+		if request.ImageWidth, request.World, request.States, err = req.newWorld(ruleOf); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		request.ImageHeight = len(request.World) + len(request.States)
+	}
+
+	// the run is claimed and set up here rather than through RunGameOfLife, so that a run the broker cannot do is
+	// refused before answering, even when not waiting for it
+	if err := g.start(); err != nil {
+		writeError(w, http.StatusConflict, err)
+		return
+	}
+	if err := g.setUpRun(request); err != nil {
+		g.stop()
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	response := new(stubs.Response)
+	if !req.Wait {
+		go func() {
+			defer g.stop()
+			g.runTurns(request, response)
+		}()
+		writeJSON(w, http.StatusAccepted, g.state())
+		return
+	}
+	g.runTurns(request, response)
+	g.stop()
+	out, err := encodeWorld(response.CompletedTurns, ruleOf.String(), response.NextWorld, response.NextStates, req.Format)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// registerAPI adds the JSON API to mux, under /api/. It makes the same calls as the controller, so that programs
+// in other languages can start runs and follow them
+func (g *GameOfLifeOperations) registerAPI(mux *http.ServeMux) {
+	var empty struct{}
+	mux.HandleFunc("/api/openapi.json", method(http.MethodGet, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, openAPI)
+	}))
+	mux.HandleFunc("/api/run", method(http.MethodPost, g.apiRunWorld))
+	mux.HandleFunc("/api/state", method(http.MethodGet, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, g.state())
+	}))
+	mux.HandleFunc("/api/alive", method(http.MethodGet, func(w http.ResponseWriter, r *http.Request) {
+		count := new(stubs.AliveCellsResponse)
+		if err := g.GetAliveCount(empty, count); err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, apiCount{Turn: count.CompletedTurns, Alive: count.AliveCellsCount})
+	}))
+	mux.HandleFunc("/api/world", method(http.MethodGet, func(w http.ResponseWriter, r *http.Request) {
+		world, ruleName := g.snapshot()
+		if world.World == nil && world.States == nil {
+			writeError(w, http.StatusConflict, errors.New("there is no world until the game of life has been run"))
+			return
+		}
+		if r, err := rule.Parse(ruleName); err == nil {
+			ruleName = r.String()
+		}
+		out, err := encodeWorld(world.CompletedTurns, ruleName, world.World, world.States, r.URL.Query().Get("format"))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, out)
+	}))
+	mux.HandleFunc("/api/pause", method(http.MethodPost, func(w http.ResponseWriter, r *http.Request) {
+		if err := g.PauseServer(empty, new(stubs.PauseServerResponse)); err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, g.state())
+	}))
+	mux.HandleFunc("/api/halt", method(http.MethodPost, func(w http.ResponseWriter, r *http.Request) {
+		if err := g.HaltTurns(empty, &empty); err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, g.state())
+	}))
+	mux.HandleFunc("/api/kill", method(http.MethodPost, func(w http.ResponseWriter, r *http.Request) {
+		// the same as the k key of the controller, the workers and broker stop once the turns do
+		if err := g.KillClients(empty, &empty); err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		if err := g.HaltTurns(empty, &empty); err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, g.state())
+	}))
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"uk.ac.bris.cs/gameoflife/rle"
+	"uk.ac.bris.cs/gameoflife/stubs"
+	"uk.ac.bris.cs/gameoflife/util"
+)
+
+// serveAPI returns a test server of the JSON API of g
+func serveAPI(g *GameOfLifeOperations) *httptest.Server {
+	mux := http.NewServeMux()
+	g.registerAPI(mux)
+	return httptest.NewServer(mux)
+}
+
+// call makes a request to the API with body encoded as JSON when it is not nil, decodes the response into out when
+// it is not nil and returns the status
+func call(t *testing.T, server *httptest.Server, method, path string, body, out interface{}) int {
+	t.Helper()
+	var encoded bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&encoded).Encode(body); err != nil {
+			t.Fatal(err)
+		}
+	}
+	req, err := http.NewRequest(method, server.URL+path, &encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if out != nil && res.StatusCode < 300 {
+		if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return res.StatusCode
+}
+
+// packBits returns world as the bits format of the API
+func packBits(world []util.BitArray) string {
+	var bits []byte
+	for _, row := range world {
+		bits = append(bits, row...)
+	}
+	return base64.StdEncoding.EncodeToString(bits)
+}
+
+// TestAPIRun checks a run that is waited for, and that the state and world afterwards match it
+func TestAPIRun(t *testing.T) {
+	g := newTestBroker(t, false)
+	server := serveAPI(g)
+	defer server.Close()
+	world := soup(32, 16, 1)
+	want := packBits(referenceTurns(world, util.Torus, 20))
+
+	var out apiWorld
+	run := apiRun{Width: 32, Height: 16, Turns: 20, Bits: packBits(world), Wait: true, Format: formatBits}
+	if status := call(t, server, http.MethodPost, "/api/run", run, &out); status != http.StatusOK {
+		t.Fatalf("POST /api/run answered %d, want %d", status, http.StatusOK)
+	}
+	if out.Turn != 20 || out.Bits != want || out.Rule != "B3/S23" {
+		t.Errorf("the run ended on turn %d with rule %s, want turn 20 of B3/S23 with the reference world", out.Turn, out.Rule)
+	}
+
+	var state apiState
+	if status := call(t, server, http.MethodGet, "/api/state", nil, &state); status != http.StatusOK || state.Running || state.Turn != 20 {
+		t.Errorf("GET /api/state answered %d with %+v, want 200 with 20 turns and not running", status, state)
+	}
+	out = apiWorld{}
+	if status := call(t, server, http.MethodGet, "/api/world?format=bits", nil, &out); status != http.StatusOK || out.Bits != want {
+		t.Errorf("GET /api/world answered %d with turn %d, want 200 with the final world", status, out.Turn)
+	}
+
+	run.Wait, run.Resume, run.Bits, run.Turns = false, true, "", 40
+	if status := call(t, server, http.MethodPost, "/api/run", run, &state); status != http.StatusAccepted || !state.Running {
+		t.Fatalf("POST /api/run without waiting answered %d with %+v, want 202 and running", status, state)
+	}
+	for deadline := time.Now().Add(10 * time.Second); state.Running && time.Now().Before(deadline); {
+		time.Sleep(time.Millisecond)
+		call(t, server, http.MethodGet, "/api/state", nil, &state)
+	}
+	if state.Running || state.Turn != 40 {
+		t.Errorf("a run resumed to 40 turns ended as %+v, want turn 40 and not running", state)
+	}
+
+	if status := call(t, server, http.MethodGet, "/api/run", nil, nil); status != http.StatusMethodNotAllowed {
+		t.Errorf("GET /api/run answered %d, want %d", status, http.StatusMethodNotAllowed)
+	}
+}
+
+// TestAPIResumeRule checks that a resumed run is answered with the rule of the world it carries on, not Life
+func TestAPIResumeRule(t *testing.T) {
+	g := editBroker(t, "B2/S/C3", util.Torus, 32, 16)
+	server := serveAPI(g)
+	defer server.Close()
+	if err := g.StampPattern(stubs.StampRequest{X: 3, Y: 2, RLE: "A2.B$.BA!"}, new(stubs.AliveCellsResponse)); err != nil {
+		t.Fatal(err)
+	}
+	var out apiWorld
+	run := apiRun{Resume: true, Wait: true}
+	if status := call(t, server, http.MethodPost, "/api/run", run, &out); status != http.StatusOK {
+		t.Fatalf("POST /api/run resuming answered %d, want %d", status, http.StatusOK)
+	}
+	pattern, err := rle.Parse(out.RLE)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.Rule != "B2/S/C3" || pattern.Rule != "B2/S/C3" || pattern.MaxState() != 2 {
+		t.Errorf("a resumed run of B2/S/C3 was answered with rule %s and the header rule %s", out.Rule, pattern.Rule)
+	}
+}
+
+// TestAPIRefused checks that runs the broker cannot do are refused before answering, whether or not they are
+// waited for, and that the broker is not left running
+func TestAPIRefused(t *testing.T) {
+	g := newTestBroker(t, false)
+	server := serveAPI(g)
+	defer server.Close()
+	if status := call(t, server, http.MethodGet, "/api/world", nil, nil); status != http.StatusConflict {
+		t.Errorf("GET /api/world before any run answered %d, want %d", status, http.StatusConflict)
+	}
+
+	bits := packBits(soup(32, 16, 2))
+	for _, run := range []apiRun{
+		{Width: 32, Height: 16, Turns: 5, Bits: bits, Rule: "B3/S23/bogus"},
+		{Width: 32, Height: 16, Turns: 5, Bits: bits, Boundary: "sphere"},
+		{Width: 30, Height: 16, Turns: 5, Bits: bits},
+		{Width: 32, Height: 16, Turns: 5, Bits: bits, Threads: -1},
+		{Width: 32, Height: 16, Turns: 5, Bits: bits, Threads: 1000000000},
+	} {
+		if status := call(t, server, http.MethodPost, "/api/run", run, nil); status != http.StatusBadRequest {
+			t.Errorf("POST /api/run of %+v answered %d, want %d", run, status, http.StatusBadRequest)
+		}
+	}
+
+	// with a worker gone the run can only fail, which is found before answering a run that is not waited for
+	mutex.Lock()
+	g.clients[0] = nil
+	mutex.Unlock()
+	run := apiRun{Width: 32, Height: 16, Turns: 5, Bits: bits}
+	if status := call(t, server, http.MethodPost, "/api/run", run, nil); status != http.StatusBadRequest {
+		t.Errorf("POST /api/run without every worker answered %d, want %d", status, http.StatusBadRequest)
+	}
+	if g.state().Running {
+		t.Error("the broker was left running after a refused run")
+	}
+}
+
+// TestAPIConflict checks that a run is refused while another is going, from the API and over RPC
+func TestAPIConflict(t *testing.T) {
+	g := newTestBroker(t, false)
+	server := serveAPI(g)
+	defer server.Close()
+	if err := g.start(); err != nil {
+		t.Fatal(err)
+	}
+
+	run := apiRun{Width: 32, Height: 16, Turns: 5, Bits: packBits(soup(32, 16, 3)), Wait: true}
+	if status := call(t, server, http.MethodPost, "/api/run", run, nil); status != http.StatusConflict {
+		t.Errorf("POST /api/run while running answered %d, want %d", status, http.StatusConflict)
+	}
+	request := stubs.Request{Turns: 5, ImageWidth: 32, ImageHeight: 16, World: soup(32, 16, 3)}
+	if err := g.RunGameOfLife(request, new(stubs.Response)); err != errRunning {
+		t.Errorf("RunGameOfLife while running returned %v, want %v", err, errRunning)
+	}
+	g.stop()
+
+	// only one of many runs started together goes ahead
+	started := make(chan bool)
+	for i := 0; i < 16; i++ {
+		go func() {
+			started <- g.start() == nil
+		}()
+	}
+	n := 0
+	for i := 0; i < 16; i++ {
+		if <-started {
+			n++
+		}
+	}
+	if n != 1 {
+		t.Errorf("%d of 16 runs started together went ahead, want 1", n)
+	}
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// workerTimings keeps a sliding window of how long each worker took per row, used to rebalance the strips
+type workerTimings struct {
+	samples [][]float64 // samples[i] is a ring buffer of nanoseconds per row for worker i
+	next    []int       // next[i] is the index in samples[i] to be overwritten next
+	filled  []int       // filled[i] is the number of valid samples held for worker i
+}
+
+// newWorkerTimings creates an empty sliding window of size window for each of the workers
+func newWorkerTimings(workers, window int) *workerTimings {
+	if window < 1 {
+		window = 1
+	}
+	t := &workerTimings{
+		samples: make([][]float64, workers),
+		next:    make([]int, workers),
+		filled:  make([]int, workers),
+	}
+	for i := range t.samples {
+		t.samples[i] = make([]float64, window)
+	}
+	return t
+}
+
+// window returns the number of samples kept for each worker
+func (t *workerTimings) window() int {
+	if len(t.samples) == 0 {
+		return 1
+	}
+	return len(t.samples[0])
+}
+
+// record adds the time a worker took to process rows to the sliding window. An elapsed time of 0 is a call that
+// failed, which is left out so that a failing worker does not look fast
+func (t *workerTimings) record(worker, rows int, elapsed time.Duration) {
+	if rows <= 0 || elapsed <= 0 {
+		return
+	}
+	t.samples[worker][t.next[worker]] = float64(elapsed.Nanoseconds()) / float64(rows)
+	t.next[worker] = (t.next[worker] + 1) % len(t.samples[worker])
+	if t.filled[worker] < len(t.samples[worker]) {
+		t.filled[worker]++
+	}
+}
+
+// perRow returns the average nanoseconds per row for each worker over the window, 0 if there are no samples
+func (t *workerTimings) perRow() []float64 {
+	averages := make([]float64, len(t.samples))
+	for i, window := range t.samples {
+		if t.filled[i] == 0 {
+			continue
+		}
+		total := 0.0
+		for j := 0; j < t.filled[i]; j++ {
+			total += window[j]
+		}
+		averages[i] = total / float64(t.filled[i])
+	}
+	return averages
+}
+
+// balancedScale splits height rows between workers proportionally to their throughput (rows per nanosecond).
+// Every worker is given at least one row when there are enough rows, and workers without any samples yet
+// are treated as running at the average speed of the others
+func balancedScale(height int, perRow []float64) []int {
+	workers := len(perRow)
+	known, total := 0, 0.0
+	for _, ns := range perRow {
+		if ns > 0 {
+			known++
+			total += ns
+		}
+	}
+	if known == 0 {
+		return threadScale(height, workers)
+	}
+	average := total / float64(known)
+
+	throughput := make([]float64, workers)
+	sum := 0.0
+	for i, ns := range perRow {
+		if ns <= 0 {
+			ns = average
+		}
+		throughput[i] = 1 / ns
+		sum += throughput[i]
+	}
+
+	minimum := 0
+	if height >= workers {
+		minimum = 1
+	}
+	spare := height - minimum*workers
+
+	// largest remainder method so that the strips always add up to the height
+	scale := make([]int, workers)
+	remainders := make([]float64, workers)
+	assigned := 0
+	for i := range scale {
+		exact := float64(spare) * throughput[i] / sum
+		scale[i] = minimum + int(exact)
+		remainders[i] = exact - float64(int(exact))
+		assigned += scale[i]
+	}
+	order := make([]int, workers)
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return remainders[order[a]] > remainders[order[b]]
+	})
+	for i := 0; assigned < height; i++ {
+		scale[order[i%workers]]++
+		assigned++
+	}
+	return scale
+}
+
+// sum adds up the rows in a split
+func sum(scale []int) int {
+	total := 0
+	for _, rows := range scale {
+		total += rows
+	}
+	return total
+}
+
+// sameScale reports whether two splits are identical
+func sameScale(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// rebalance recalculates the strip heights from the measured timings and logs the split when it changes
+func (g *GameOfLifeOperations) rebalance(height int) {
+	scale := balancedScale(height, g.timings.perRow())
+	if sameScale(scale, g.scale) {
+		return
+	}
+	g.scale = scale
+	fmt.Println("#REBALANCED turn", g.CompletedTurns, "split", g.scale)
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// TestBalancedScale checks that the strips always add up to the height, that every worker gets a row when there
+// are enough, and that faster workers get more rows
+func TestBalancedScale(t *testing.T) {
+	tests := []struct {
+		height int
+		perRow []float64
+		want   []int
+	}{
+		{16, []float64{0, 0, 0, 0}, []int{4, 4, 4, 4}},
+		{18, []float64{0, 0, 0, 0}, []int{5, 5, 4, 4}},
+		{16, []float64{1, 1, 1, 1}, []int{4, 4, 4, 4}},
+		{12, []float64{1, 2, 2, 2}, []int{4, 3, 3, 2}},
+		{100, []float64{1, 1000000, 1000000, 1000000}, []int{97, 1, 1, 1}},
+		{16, []float64{1, 0, 1, 0}, []int{4, 4, 4, 4}},
+		{10, []float64{1, 3, 3, 3}, []int{4, 2, 2, 2}},
+		{3, []float64{1, 1, 1, 1}, []int{1, 1, 1, 0}},
+		{0, []float64{1, 2, 3, 4}, []int{0, 0, 0, 0}},
+	}
+	for _, test := range tests {
+		got := balancedScale(test.height, test.perRow)
+		if !sameScale(got, test.want) {
+			t.Errorf("balancedScale(%d, %v) = %v, want %v", test.height, test.perRow, got, test.want)
+		}
+	}
+
+	for height := 0; height < 200; height += 7 {
+		for _, perRow := range [][]float64{{5, 1, 3, 2}, {0.5, 0, 100, 7}, {1e9, 1, 1, 1e9}} {
+			scale := balancedScale(height, perRow)
+			if sum(scale) != height {
+				t.Errorf("balancedScale(%d, %v) = %v, which adds up to %d", height, perRow, scale, sum(scale))
+			}
+			for _, rows := range scale {
+				if height >= len(perRow) && rows < 1 {
+					t.Errorf("balancedScale(%d, %v) = %v, which leaves a worker without rows", height, perRow, scale)
+				}
+			}
+		}
+	}
+}
+
+// TestWorkerTimings checks that only the last window samples of each worker are averaged, and that failed calls
+// and empty strips are not counted
+func TestWorkerTimings(t *testing.T) {
+	timings := newWorkerTimings(2, 3)
+	if got := timings.perRow(); got[0] != 0 || got[1] != 0 {
+		t.Errorf("perRow() = %v before any samples, want zeros", got)
+	}
+	timings.record(0, 10, 100*time.Nanosecond)
+	timings.record(0, 10, 200*time.Nanosecond)
+	timings.record(1, 0, time.Second)
+	timings.record(1, 5, 0)
+	if got := timings.perRow(); got[0] != 15 || got[1] != 0 {
+		t.Errorf("perRow() = %v, want [15 0]", got)
+	}
+	for _, ns := range []time.Duration{300, 400, 500} {
+		timings.record(0, 1, ns)
+	}
+	if got := timings.perRow(); got[0] != 400 {
+		t.Errorf("perRow() = %v after the window filled, want the average of the last 3 samples, 400", got)
+	}
+	if timings.window() != 3 {
+		t.Errorf("window() = %d, want 3", timings.window())
+	}
+}
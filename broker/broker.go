@@ -1,13 +1,16 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"net"
 	"net/rpc"
-	"os"
 	"sync"
 	"time"
+	"uk.ac.bris.cs/gameoflife/hashlife"
+	pb "uk.ac.bris.cs/gameoflife/proto"
+	"uk.ac.bris.cs/gameoflife/rule"
 	"uk.ac.bris.cs/gameoflife/stubs"
 	"uk.ac.bris.cs/gameoflife/util"
 )
@@ -17,6 +20,7 @@ var mutex sync.Mutex // Mutex for safe access to the global channel
 // Result represents the result of the executeTurns function
 type Result struct {
 	World      []util.BitArray
+	States     []util.StateArray
 	AliveCells int
 }
 
@@ -26,9 +30,24 @@ type GameOfLifeOperations struct {
 	CompletedTurns int
 	haltTurns      bool
 	pause          bool
-	clients        []*rpc.Client
+	clients        []stubs.Caller
 	killClients    bool
 	killBroker     bool
+	scale          []int              // height of the strip given to each worker, only to be accessed with the mutex
+	timings        *workerTimings     // sliding window of time per row for each worker
+	rebalanceEvery int                // number of turns between rebalancing the strips, 0 disables rebalancing
+	sparse         bool               // only send and recompute tiles that are near a change from two turns ago
+	activity       *util.Activity     // tiles that changed since two turns ago, only to be accessed with the mutex
+	universe       *hashlife.Universe // the world when using the HashLife engine, only to be accessed with the mutex
+	universeStart  int                // the turn on which the HashLife engine was started
+	boundary       util.Boundary      // how the edges of the world are joined
+	rule           string             // the rule string, the world is in States instead of World for any rule but Life
+	radius         int                // how far the rule reads, which is the number of ghost rows each strip needs
+	threads        int                // the number of goroutines the controller asked each worker to use
+	States         []util.StateArray  // the current world for rules other than Life, only to be accessed with the mutex
+	running        bool               // whether RunGameOfLife is carrying out turns, only to be accessed with the mutex
+	hello          stubs.Hello        // what the broker and all of its workers support
+	watchers       map[*watcher]bool  // the WatchTurns calls to send the cells that flip to, only to be accessed with the mutex
 }
 
 // AliveCount counts the number of alive cells in the world, and returns this as an int
@@ -60,43 +79,37 @@ func threadScale(height, threads int) []int {
 	return scale
 }
 
-// transformY ensures that negative values wrap around to avoid index out of range, and for the game to work properly when counting neighbours
-func transformY(value, height int) int {
-	if value == -1 {
-		return height - 1
-	}
-	return (value + height) % height
-}
-
-// makeWorkerCall performs a call to a worker client and returns the processed part of the world
-func makeWorkerCall(scale, worldWidth int, inPart []util.BitArray, client *rpc.Client, resultChannel chan []util.BitArray) {
+// makeWorkerCall performs a call to a worker client and returns the processed part of the world, elapsed is set to the time the call took, or left at 0 if it failed
+func makeWorkerCall(request stubs.WorkerRequest, client stubs.Caller, resultChannel chan []util.BitArray, elapsed *time.Duration) {
 	var workerResponse stubs.WorkerResponse
-	request := stubs.WorkerRequest{
-		Scale:      scale,
-		WorldWidth: worldWidth,
-		InPart:     inPart,
-	}
+	start := time.Now()
 	if err := client.Call(stubs.Worker, request, &workerResponse); err != nil {
 		fmt.Println("RPC call error:", err)
+	} else {
+		*elapsed = time.Since(start)
 	}
 	resultChannel <- workerResponse.OutPart
 }
 
-// killWorkersCall kills all worker clients that it is given
-func killWorkersCall(clients []*rpc.Client) {
+// killWorkersCall kills all worker clients that it is given, skipping the workers that are not connected
+func killWorkersCall(clients []stubs.Caller) {
 	var workerResponse struct{}
 	for i := range clients {
+		if clients[i] == nil {
+			continue
+		}
 		if err := clients[i].Call(stubs.KillWorker, struct{}{}, &workerResponse); err != nil {
 			fmt.Println("RPC call error:", err)
 		}
 	}
 }
 
-// connectToWorkers returns []rpc.Client, a slice of clients that are ready for RPC calls
-func connectToWorkers(serverAddresses []string) []*rpc.Client {
-	clients := make([]*rpc.Client, stubs.Threads)
+// connectToWorkers returns a slice of clients that are ready for RPC calls, over gRPC when overGRPC is set. Workers
+// that cannot be reached are left nil
+func connectToWorkers(serverAddresses []string, overGRPC bool) []stubs.Caller {
+	clients := make([]stubs.Caller, stubs.Threads)
 	for i := range clients {
-		dial, err := rpc.Dial("tcp", serverAddresses[i])
+		dial, err := pb.DialCaller(serverAddresses[i], overGRPC)
 		clients[i] = dial
 		if err != nil {
 			fmt.Println(err)
@@ -105,81 +118,194 @@ func connectToWorkers(serverAddresses []string) []*rpc.Client {
 	return clients
 }
 
+// denseTurn carries out a single turn by sending every row of the world to the workers
+func denseTurn(Width int, Height int, g *GameOfLifeOperations) {
+	scale := g.scale
+	elapsed := make([]time.Duration, stubs.Threads)
+	nextWorld := make([]util.BitArray, 0)
+	//iterate through each cell in the current world
+
+	workerResponses := make([]chan []util.BitArray, stubs.Threads) // rows
+	for i := 0; i < stubs.Threads; i++ {
+		workerResponses[i] = make(chan []util.BitArray) //2d slice  //columns
+	}
+	//initiates go routines
+	startY, endY := 0, 0 //inclusive, exclusive
+	for i := range workerResponses {
+		endY = startY + scale[i] //endY is exclusive
+
+		// cuts up world into parts needed for each thread
+		inPart := make([]util.BitArray, 0)
+		for j := startY - 1; j < endY+1; j++ {
+			inPart = append(inPart, g.boundary.Row(g.World, j))
+		}
+		west, east := g.boundary.Edges(g.World, startY-1, len(inPart))
+		request := stubs.WorkerRequest{
+			Scale:      scale[i],
+			Threads:    g.threads,
+			WorldWidth: Width,
+			InPart:     inPart,
+			Boundary:   g.boundary,
+			West:       west,
+			East:       east,
+		}
+
+		go makeWorkerCall(request, g.clients[i], workerResponses[i], &elapsed[i])
+
+		startY = endY
+	}
+	//receives response
+	for i, ch := range workerResponses {
+		part := <-ch
+		nextWorld = append(nextWorld, part...)
+		g.timings.record(i, scale[i], elapsed[i])
+	}
+
+	//copy nextWorld to world
+	for row := range g.World {
+		copy(g.World[row], nextWorld[row])
+	}
+}
+
 // executeTurns Carries out the turns of the game of life by calling the workers
 func executeTurns(Turns int, Width int, Height int, g *GameOfLifeOperations) {
-	scale := threadScale(Height, stubs.Threads)
+	mutex.Lock()
+	if len(g.scale) != stubs.Threads || sum(g.scale) != Height {
+		g.scale = threadScale(Height, stubs.Threads)
+		g.timings = newWorkerTimings(stubs.Threads, g.timings.window())
+	}
+	if g.activity == nil || !g.activity.Fits(Width, Height, g.boundary) {
+		g.activity = util.NewActivity(g.World, g.boundary)
+	}
+	mutex.Unlock()
 	for g.CompletedTurns < Turns && !g.haltTurns {
 		for g.pause {
 			time.Sleep(500 * time.Millisecond) // A short pause to avoid spinning
 		}
 		mutex.Lock()
-		nextWorld := make([]util.BitArray, 0)
-		//iterate through each cell in the current world
-
-		workerResponses := make([]chan []util.BitArray, stubs.Threads) // rows
-		for i := 0; i < stubs.Threads; i++ {
-			workerResponses[i] = make(chan []util.BitArray) //2d slice  //columns
+		before := g.beforeTurn()
+		if g.universe != nil {
+			hashLifeTurns(Turns, g)
+			g.publish(before)
+			mutex.Unlock()
+			continue
 		}
-		//initiates go routines
-		startY, endY := 0, 0 //inclusive, exclusive
-		for i := range workerResponses {
-			endY = startY + scale[i] //endY is exclusive
-
-			// cuts up world into parts needed for each thread
-			inPart := make([]util.BitArray, 0)
-			for j := startY - 1; j < endY+1; j++ {
-				inPart = append(inPart, g.World[transformY(j, Height)])
-			}
-
-			go makeWorkerCall(scale[i], Width, inPart, g.clients[i], workerResponses[i])
-
-			startY = endY
-		}
-		//receives response
-		for _, ch := range workerResponses {
-			part := <-ch
-			nextWorld = append(nextWorld, part...)
-		}
-
-		//copy nextWorld to world
-		for row := range g.World {
-			copy(g.World[row], nextWorld[row])
+		if g.States != nil {
+			stateTurn(Width, Height, g)
+		} else if g.sparse {
+			sparseTurn(Width, Height, g)
+		} else {
+			denseTurn(Width, Height, g)
 		}
 		g.CompletedTurns++
+		g.publish(before)
+		if g.rebalanceEvery > 0 && g.CompletedTurns%g.rebalanceEvery == 0 {
+			g.rebalance(Height)
+		}
 		mutex.Unlock()
 	}
 	if g.killClients {
 		killWorkersCall(g.clients)
 		g.killBroker = true
 	}
-	result := Result{World: g.World, AliveCells: AliveCount(g.World)}
+	mutex.Lock()
+	result := Result{World: g.currentWorld(), States: g.States, AliveCells: g.aliveCount()}
+	mutex.Unlock()
 	g.ResultChannel <- result
 }
 
+// errRunning is returned to a second run started while the broker is still carrying out the turns of the first
+var errRunning = errors.New("the broker is already running the game of life")
+
+// start marks the broker as running, testing and setting in one go so that only one of two runs started together
+// goes ahead. It returns errRunning if the broker was already running
+func (g *GameOfLifeOperations) start() error {
+	mutex.Lock()
+	defer mutex.Unlock()
+	if g.running {
+		return errRunning
+	}
+	g.running = true
+	return nil
+}
+
+// stop marks the broker as no longer running
+func (g *GameOfLifeOperations) stop() {
+	mutex.Lock()
+	g.running = false
+	mutex.Unlock()
+}
+
 // RunGameOfLife is called to Run game of life, it must assume that it has already been called
 func (g *GameOfLifeOperations) RunGameOfLife(req stubs.Request, res *stubs.Response) (err error) {
+	if err := g.start(); err != nil {
+		return err
+	}
+	defer g.stop()
+	if err := g.setUpRun(req); err != nil {
+		return err
+	}
+	g.runTurns(req, res)
+	return nil
+}
+
+// setUpRun takes the new world of req, or carries on from the current one when req resumes, returning an error if
+// the broker cannot run it. It must be called once the broker has been marked as running
+func (g *GameOfLifeOperations) setUpRun(req stubs.Request) error {
+	if req.Threads < 0 || req.Threads > stubs.MaxThreads {
+		return fmt.Errorf("%d threads for each worker is not between 0 and %d", req.Threads, stubs.MaxThreads)
+	}
+	mutex.Lock()
+	defer mutex.Unlock()
 	g.haltTurns = false
 	g.pause = false
-	if g.World != nil && req.Resume {
+	g.threads = req.Threads
+	if (g.World != nil || g.States != nil) && req.Resume {
 		fmt.Println("#RESUMING")
 	} else {
+		if err := g.supports(req); err != nil {
+			return err
+		}
+		radius, states := 1, []util.StateArray(nil)
+		if !rule.IsLife(req.Rule) {
+			r, err := rule.Parse(req.Rule)
+			if err != nil {
+				return err
+			}
+			radius, states = r.Radius(), req.States
+		}
 		g.World = req.World
 		g.CompletedTurns = 0
+		g.activity = nil
+		g.universe = nil
+		g.boundary = req.Boundary
+		g.rule = req.Rule
+		g.radius = radius
+		g.States = states
+	}
+	g.useEngine(req.Engine)
+	if workers := g.workers(); g.universe == nil && workers < stubs.Threads {
+		return fmt.Errorf("only %d of the %d workers are connected", workers, stubs.Threads)
 	}
+	return nil
+}
 
+// runTurns carries out the turns of a run that has been set up, and waits for the final world
+func (g *GameOfLifeOperations) runTurns(req stubs.Request, res *stubs.Response) {
 	go executeTurns(req.Turns, req.ImageWidth, req.ImageHeight, g)
 	// Wait for the result from the executeTurns
 	result := <-g.ResultChannel
 	res.NextWorld = result.World
+	res.NextStates = result.States
+	mutex.Lock()
 	res.CompletedTurns = g.CompletedTurns
-	return
-
+	mutex.Unlock()
 }
 
 // GetAliveCount is called when the 2-second timer calls it from the client
 func (g *GameOfLifeOperations) GetAliveCount(_ struct{}, res *stubs.AliveCellsResponse) (err error) {
 	mutex.Lock()
-	res.AliveCellsCount = AliveCount(g.World)
+	res.AliveCellsCount = g.aliveCount()
 	res.CompletedTurns = g.CompletedTurns
 	mutex.Unlock()
 	return
@@ -189,7 +315,8 @@ func (g *GameOfLifeOperations) GetAliveCount(_ struct{}, res *stubs.AliveCellsRe
 func (g *GameOfLifeOperations) GetCurrentWorld(_ struct{}, res *stubs.CurrentWorldResponse) (err error) {
 	mutex.Lock()
 	defer mutex.Unlock()
-	res.World = g.World
+	res.World = g.currentWorld()
+	res.States = g.States
 	res.CompletedTurns = g.CompletedTurns
 	return
 }
@@ -219,18 +346,42 @@ func (g *GameOfLifeOperations) PauseServer(_ struct{}, res *stubs.PauseServerRes
 	return
 }
 
+// GetWorkerStats is an RPC method, it returns the current split of rows between the workers and their measured time per row
+func (g *GameOfLifeOperations) GetWorkerStats(_ struct{}, res *stubs.WorkerStatsResponse) (err error) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	res.Split = append([]int(nil), g.scale...)
+	if g.timings != nil {
+		res.NsPerRow = g.timings.perRow()
+	}
+	res.CompletedTurns = g.CompletedTurns
+	res.Workers = g.workers()
+	return
+}
+
 // main initialises the server and adds a way to kill it
 func main() {
 	pAddr := flag.String("port", "8030", "Port to listen on")
+	rebalance := flag.Int("rebalance", 10, "Number of turns between rebalancing the strips given to each worker, 0 to split evenly")
+	window := flag.Int("window", 20, "Number of turns of timings kept for each worker when rebalancing")
+	sparse := flag.Bool("sparse", true, "Only recompute tiles of the world that are near a change from two turns ago")
+	dashboard := flag.String("http", "", "Address to serve the web dashboard and JSON API on, such as :8080, empty for neither")
+	frameEvery := flag.Duration("frameEvery", 250*time.Millisecond, "Time between the frames streamed to the web dashboard")
+	grpcAddr := flag.String("grpc", "", "Address to serve the broker over gRPC on as well as net/rpc, such as :8040, empty for none")
+	grpcWorkers := flag.Bool("grpcworkers", false, "Call the workers over gRPC, at the addresses they serve it on with -grpc")
 	flag.Parse()
 	g := new(GameOfLifeOperations)
 	g.ResultChannel = make(chan Result)
 	g.haltTurns = false
 	g.killBroker = false
+	g.rebalanceEvery = *rebalance
+	g.timings = newWorkerTimings(stubs.Threads, *window)
+	g.sparse = *sparse
 	serverAddresses := make([]string, 4)
+	args := flag.Args()
 
-	if len(os.Args) == 5 {
-		copy(serverAddresses, os.Args[1:5])
+	if len(args) == 4 {
+		copy(serverAddresses, args)
 		fmt.Println("#USING ARGUMENT ADDRESSES")
 	} else {
 		serverAddresses = []string{
@@ -242,10 +393,17 @@ func main() {
 		fmt.Println("#USING DEFAULT ADDRESSES")
 	}
 
-	g.clients = connectToWorkers(serverAddresses)
+	g.clients = connectToWorkers(serverAddresses, *grpcWorkers)
+	g.greetWorkers(serverAddresses)
 	if err := rpc.Register(g); err != nil {
 		fmt.Println(err)
 	}
+	if *dashboard != "" {
+		go g.serveDashboard(*dashboard, *frameEvery)
+	}
+	if *grpcAddr != "" {
+		go g.serveGRPC(*grpcAddr)
+	}
 	listener, err2 := net.Listen("tcp", ":"+*pAddr)
 	if err2 != nil {
 		fmt.Println(err2)
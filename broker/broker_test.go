@@ -0,0 +1,192 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"net"
+	"net/rpc"
+	"testing"
+	"uk.ac.bris.cs/gameoflife/stubs"
+	"uk.ac.bris.cs/gameoflife/util"
+)
+
+// testWorker stands in for a worker in the tests of the broker, running Life on the strips and halos it is sent
+// one cell at a time
+type testWorker struct{}
+
+// alive returns whether the cell x, y of rows is alive, following the boundary beyond the left and right edges
+func alive(rows []util.BitArray, west, east []bool, boundary util.Boundary, x, y int) bool {
+	width := rows[y].Len()
+	if boundary == util.CrossSurface && (x < 0 || x >= width) {
+		if x < 0 {
+			return west[y]
+		}
+		return east[y]
+	}
+	wx, _, ok := boundary.Wrap(x, 0, width, 1)
+	return ok && rows[y].GetBit(wx)
+}
+
+// next returns whether the cell x, y of rows is alive on the next turn
+func next(rows []util.BitArray, west, east []bool, boundary util.Boundary, x, y int) bool {
+	neighbours := 0
+	for dy := -1; dy <= 1; dy++ {
+		for dx := -1; dx <= 1; dx++ {
+			if (dx != 0 || dy != 0) && alive(rows, west, east, boundary, x+dx, y+dy) {
+				neighbours++
+			}
+		}
+	}
+	return neighbours == 3 || neighbours == 2 && rows[y].GetBit(x)
+}
+
+func (testWorker) Worker(req stubs.WorkerRequest, res *stubs.WorkerResponse) error {
+	if !req.Sparse {
+		for y := 1; y <= req.Scale; y++ {
+			row := util.NewBitArray(req.WorldWidth)
+			for x := 0; x < req.WorldWidth; x++ {
+				row.SetBit(x, next(req.InPart, req.West, req.East, req.Boundary, x, y))
+			}
+			res.OutPart = append(res.OutPart, row)
+		}
+		return nil
+	}
+	for i, tile := range req.ActiveTiles {
+		halo := req.Halos[i]
+		x0, x1, y0, y1 := util.TileBounds(tile, req.WorldWidth, req.StartY, req.StartY+req.Scale)
+		out := util.Tile{X: x0, Y: y0}
+		for y := y0; y < y1; y++ {
+			row := util.NewBitArray(x1 - x0)
+			for x := x0; x < x1; x++ {
+				row.SetBit(x-x0, next(halo.Rows, nil, nil, util.DeadBorder, x-halo.X, y-halo.Y))
+			}
+			out.Rows = append(out.Rows, row)
+		}
+		res.Tiles = append(res.Tiles, out)
+	}
+	return nil
+}
+
+func (testWorker) KillWorker(_ struct{}, _ *struct{}) error {
+	return nil
+}
+
+// newTestBroker returns a broker connected to stubs.Threads test workers
+func newTestBroker(t *testing.T, sparse bool) *GameOfLifeOperations {
+	g := &GameOfLifeOperations{
+		ResultChannel:  make(chan Result),
+		rebalanceEvery: 10,
+		timings:        newWorkerTimings(stubs.Threads, 20),
+		sparse:         sparse,
+		hello:          brokerHello(),
+	}
+	server := rpc.NewServer()
+	if err := server.RegisterName("WorkerOperations", testWorker{}); err != nil {
+		t.Fatal(err)
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go server.Accept(listener)
+	for i := 0; i < stubs.Threads; i++ {
+		client, err := rpc.Dial("tcp", listener.Addr().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		g.clients = append(g.clients, client)
+	}
+	return g
+}
+
+// soup returns a width by height world with about a third of its cells alive
+func soup(width, height int, seed int64) []util.BitArray {
+	random := rand.New(rand.NewSource(seed))
+	world := make([]util.BitArray, height)
+	for y := range world {
+		world[y] = util.NewBitArray(width)
+		for x := 0; x < width; x++ {
+			world[y].SetBit(x, random.Intn(3) == 0)
+		}
+	}
+	return world
+}
+
+// referenceTurns runs world for turns turns by wrapping every neighbour of every cell with the boundary
+func referenceTurns(world []util.BitArray, boundary util.Boundary, turns int) []util.BitArray {
+	width, height := world[0].Len(), len(world)
+	for turn := 0; turn < turns; turn++ {
+		nextWorld := make([]util.BitArray, height)
+		for y := range nextWorld {
+			nextWorld[y] = util.NewBitArray(width)
+			for x := 0; x < width; x++ {
+				neighbours := 0
+				for dy := -1; dy <= 1; dy++ {
+					for dx := -1; dx <= 1; dx++ {
+						wx, wy, ok := boundary.Wrap(x+dx, y+dy, width, height)
+						if (dx != 0 || dy != 0) && ok && world[wy].GetBit(wx) {
+							neighbours++
+						}
+					}
+				}
+				nextWorld[y].SetBit(x, neighbours == 3 || neighbours == 2 && world[y].GetBit(x))
+			}
+		}
+		world = nextWorld
+	}
+	return world
+}
+
+// run runs world on a broker for turns turns and returns the final world
+func run(t *testing.T, g *GameOfLifeOperations, world []util.BitArray, boundary util.Boundary, turns int) []util.BitArray {
+	request := stubs.Request{Turns: turns, ImageWidth: world[0].Len(), ImageHeight: len(world), World: world, Boundary: boundary}
+	response := new(stubs.Response)
+	if err := g.RunGameOfLife(request, response); err != nil {
+		t.Fatal(err)
+	}
+	if response.CompletedTurns != turns {
+		t.Fatalf("completed %d turns, want %d", response.CompletedTurns, turns)
+	}
+	return response.NextWorld
+}
+
+// assertEqualWorld fails the test if got is not the same world as want
+func assertEqualWorld(t *testing.T, got, want []util.BitArray, name string) {
+	t.Helper()
+	for y := range want {
+		if fmt.Sprint(got[y]) != fmt.Sprint(want[y]) {
+			t.Fatalf("row %d is %v with %s turns, want %v", y, got[y], name, want[y])
+		}
+	}
+}
+
+// TestSparseTurns checks that a broker sending only the active tiles ends up with the same world as one sending every
+// row, and as the reference, for each boundary
+func TestSparseTurns(t *testing.T) {
+	const turns = 150
+	for boundary := util.Torus; boundary <= util.CrossSurface; boundary++ {
+		t.Run(boundary.String(), func(t *testing.T) {
+			expected := referenceTurns(soup(72, 40, int64(boundary)), boundary, turns)
+			assertEqualWorld(t, run(t, newTestBroker(t, false), soup(72, 40, int64(boundary)), boundary, turns), expected, "dense")
+			assertEqualWorld(t, run(t, newTestBroker(t, true), soup(72, 40, int64(boundary)), boundary, turns), expected, "sparse")
+		})
+	}
+}
+
+// TestKillWithMissingWorker checks that a HashLife run, which goes ahead without all of the workers, can still be
+// ended by killing the workers that are connected
+func TestKillWithMissingWorker(t *testing.T) {
+	g := newTestBroker(t, false)
+	g.clients[1] = nil
+	world := soup(32, 32, 8)
+	request := stubs.Request{Turns: 10, ImageWidth: 32, ImageHeight: 32, World: world, Engine: stubs.EngineHashLife}
+	if err := g.KillClients(struct{}{}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.RunGameOfLife(request, new(stubs.Response)); err != nil {
+		t.Fatal(err)
+	}
+	if !g.killBroker {
+		t.Error("killing the workers of a HashLife run with one missing did not stop the broker")
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+// dashboardPage is the web dashboard, kept in the binary so that the broker can be run on its own. It draws the
+// frames from /frames on a canvas, inflating their cells with the browser's DecompressionStream, and its buttons
+// post to the control endpoints
+const dashboardPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Game of Life broker</title>
+<style>
+	body { background: #181818; color: #e0e0e0; font-family: sans-serif; margin: 1em; }
+	#world { background: #000; image-rendering: pixelated; width: min(90vh, 90vw); border: 1px solid #303030; }
+	#panel { display: inline-block; vertical-align: top; margin-left: 1em; }
+	dt { color: #909090; }
+	dd { margin: 0 0 0.5em 0; font-size: 1.3em; }
+	button { display: block; width: 8em; margin: 0.3em 0; padding: 0.4em; }
+	#status { color: #c06060; }
+</style>
+</head>
+<body>
+<canvas id="world" width="1" height="1"></canvas>
+<div id="panel">
+	<dl>
+		<dt>Turn</dt><dd id="turn">-</dd>
+		<dt>Alive cells</dt><dd id="alive">-</dd>
+		<dt>Turns per second</dt><dd id="rate">-</dd>
+		<dt>State</dt><dd id="state">-</dd>
+		<dt>World</dt><dd id="size">-</dd>
+		<dt>Workers</dt><dd id="workers">-</dd>
+	</dl>
+	<button id="pause">Pause</button>
+	<button id="save">Save</button>
+	<button id="halt">Halt</button>
+	<button id="kill">Kill</button>
+	<p id="status"></p>
+</div>
+<script>
+"use strict";
+const canvas = document.getElementById("world");
+const context = canvas.getContext("2d");
+const show = (id, text) => { document.getElementById(id).textContent = text; };
+
+async function inflate(base64) {
+	const bytes = Uint8Array.from(atob(base64), c => c.charCodeAt(0));
+	const stream = new Blob([bytes]).stream().pipeThrough(new DecompressionStream("deflate"));
+	return new Uint8Array(await new Response(stream).arrayBuffer());
+}
+
+async function draw(frame) {
+	const cells = await inflate(frame.cells);
+	if (canvas.width !== frame.frameWidth || canvas.height !== frame.frameHeight) {
+		canvas.width = frame.frameWidth;
+		canvas.height = frame.frameHeight;
+	}
+	const image = context.createImageData(frame.frameWidth, frame.frameHeight);
+	const rowBytes = (frame.frameWidth + 7) >> 3;
+	for (let y = 0; y < frame.frameHeight; y++) {
+		for (let x = 0; x < frame.frameWidth; x++) {
+			const alive = (cells[y * rowBytes + (x >> 3)] >> (x & 7)) & 1;
+			const i = 4 * (y * frame.frameWidth + x);
+			image.data[i] = image.data[i + 1] = image.data[i + 2] = alive ? 255 : 0;
+			image.data[i + 3] = 255;
+		}
+	}
+	context.putImageData(image, 0, 0);
+	show("turn", frame.turn);
+	show("alive", frame.alive);
+	show("rate", frame.rate.toFixed(1));
+	show("state", frame.paused ? "Paused" : "Executing");
+	show("size", frame.width + " x " + frame.height);
+	show("workers", frame.workers);
+	document.getElementById("pause").textContent = frame.paused ? "Resume" : "Pause";
+}
+
+let drawing = false;
+const frames = new EventSource("frames");
+frames.onmessage = async message => {
+	if (drawing) {
+		return; // a slow browser skips frames rather than falling behind
+	}
+	drawing = true;
+	try {
+		await draw(JSON.parse(message.data));
+		show("status", "");
+	} catch (err) {
+		show("status", err);
+	} finally {
+		drawing = false;
+	}
+};
+frames.onerror = () => show("status", "Lost the connection to the broker");
+
+for (const name of ["pause", "halt", "kill"]) {
+	document.getElementById(name).onclick = async () => {
+		const response = await fetch(name, { method: "POST" });
+		show("status", response.ok ? "" : await response.text());
+	};
+}
+document.getElementById("save").onclick = () => { window.location = "world.pgm"; };
+</script>
+</body>
+</html>
+`
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"uk.ac.bris.cs/gameoflife/hashlife"
+	"uk.ac.bris.cs/gameoflife/rle"
+	"uk.ac.bris.cs/gameoflife/rule"
+	"uk.ac.bris.cs/gameoflife/stubs"
+	"uk.ac.bris.cs/gameoflife/util"
+)
+
+// edit applies a change to the current world between two turns. apply is given the number of states of the rule,
+// the size of the world and a function that sets a cell, which wraps around the torus and ignores cells off the
+// edge of other boundaries. If apply returns an error the world is left unchanged
+func (g *GameOfLifeOperations) edit(res *stubs.AliveCellsResponse, apply func(states, width, height int, set func(x, y int, state uint8)) error) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+	if g.World == nil && g.States == nil && g.universe == nil {
+		return errors.New("there is no world to edit until the game of life has been run")
+	}
+	if g.universe != nil {
+		g.World = g.universe.World()
+	}
+
+	states, width, height := 2, 0, len(g.World)
+	if g.States != nil {
+		r, err := rule.Parse(g.rule)
+		if err != nil {
+			return err
+		}
+		states, height = r.States(), len(g.States)
+		width = g.States[0].Len()
+	} else if height > 0 {
+		width = g.World[0].Len()
+	}
+	if width == 0 || height == 0 {
+		return errors.New("there is no world to edit as it is empty")
+	}
+
+	set := func(x, y int, state uint8) {
+		if g.boundary == util.Torus {
+			x, y = ((x%width)+width)%width, ((y%height)+height)%height
+		} else if x < 0 || x >= width || y < 0 || y >= height {
+			return
+		}
+		if g.States != nil {
+			g.States[y].Set(x, state)
+		} else {
+			g.World[y].SetBit(x, state != 0)
+		}
+	}
+	if err := apply(states, width, height, set); err != nil {
+		return err
+	}
+
+	// an edit breaks the link between the world and the turn before it, which the sparse tiles and HashLife rely on
+	if g.activity != nil && g.States == nil {
+		g.activity = util.NewActivity(g.World, g.boundary)
+	}
+	if g.universe != nil {
+		universe, err := hashlife.New(g.World)
+		util.Check(err)
+		g.universe = universe
+		g.universeStart = g.CompletedTurns
+	}
+	res.AliveCellsCount = g.aliveCount()
+	res.CompletedTurns = g.CompletedTurns
+	return nil
+}
+
+// clip returns the part of a span of length cells from start that is worth setting in a world of size cells. On the
+// torus that is at most size cells, as the rest wraps onto them again, and on other boundaries it is the part
+// inside the world. end is not above start when nothing is left
+func clip(start, length, size int, torus bool) (int, int) {
+	if torus {
+		if length > size {
+			length = size
+		}
+		return start, start + length
+	}
+	end := start + length
+	if start < 0 {
+		start = 0
+	}
+	if end > size {
+		end = size
+	}
+	return start, end
+}
+
+// SetRegion is an RPC method, it sets every cell of a rectangle to one state between two turns. The rectangle is
+// clipped to the world, and one with no cells in the world is refused
+func (g *GameOfLifeOperations) SetRegion(req stubs.RegionRequest, res *stubs.AliveCellsResponse) (err error) {
+	return g.edit(res, func(states, width, height int, set func(x, y int, state uint8)) error {
+		if int(req.State) >= states {
+			return fmt.Errorf("state %d is not one of the %d states of the rule", req.State, states)
+		}
+		if req.Width < 0 || req.Height < 0 {
+			return fmt.Errorf("region of %dx%d has a negative size", req.Width, req.Height)
+		}
+		x0, x1 := clip(req.X, req.Width, width, g.boundary == util.Torus)
+		y0, y1 := clip(req.Y, req.Height, height, g.boundary == util.Torus)
+		if x0 >= x1 || y0 >= y1 {
+			return fmt.Errorf("region of %dx%d at %d, %d has no cells in the %dx%d world", req.Width, req.Height, req.X, req.Y, width, height)
+		}
+		for y := y0; y < y1; y++ {
+			for x := x0; x < x1; x++ {
+				set(x, y, req.State)
+			}
+		}
+		return nil
+	})
+}
+
+// StampPattern is an RPC method, it writes a pattern in RLE over the world between two turns. A pattern larger than
+// the world is refused before it is decoded, and the pattern is clipped to the world like the rectangle of SetRegion
+func (g *GameOfLifeOperations) StampPattern(req stubs.StampRequest, res *stubs.AliveCellsResponse) (err error) {
+	return g.edit(res, func(states, width, height int, set func(x, y int, state uint8)) error {
+		pattern, err := rle.ParseWithin(req.RLE, width, height)
+		if err != nil {
+			return err
+		}
+		if int(pattern.MaxState()) >= states {
+			return fmt.Errorf("the pattern has state %d but the rule only has %d states", pattern.MaxState(), states)
+		}
+		x0, x1 := clip(req.X, pattern.Width, width, g.boundary == util.Torus)
+		y0, y1 := clip(req.Y, pattern.Height, height, g.boundary == util.Torus)
+		if x0 >= x1 || y0 >= y1 {
+			return fmt.Errorf("pattern of %dx%d at %d, %d has no cells in the %dx%d world", pattern.Width, pattern.Height, req.X, req.Y, width, height)
+		}
+		for y := y0; y < y1; y++ {
+			for x := x0; x < x1; x++ {
+				set(x, y, pattern.At(x-req.X, y-req.Y))
+			}
+		}
+		return nil
+	})
+}
+
+// ClearWorld is an RPC method, it kills every cell between two turns
+func (g *GameOfLifeOperations) ClearWorld(_ struct{}, res *stubs.AliveCellsResponse) (err error) {
+	return g.edit(res, func(_, _, _ int, _ func(x, y int, state uint8)) error {
+		for _, row := range g.World {
+			for i := range row {
+				row[i] = 0
+			}
+		}
+		for _, row := range g.States {
+			for i := range row.Data {
+				row.Data[i] = 0
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"testing"
+	"uk.ac.bris.cs/gameoflife/rle"
+	"uk.ac.bris.cs/gameoflife/rule"
+	"uk.ac.bris.cs/gameoflife/stubs"
+	"uk.ac.bris.cs/gameoflife/util"
+)
+
+// editBroker returns a broker with a dead width by height world of the rule on the boundary, as if it had been run
+func editBroker(t *testing.T, ruleName string, boundary util.Boundary, width, height int) *GameOfLifeOperations {
+	g := newTestBroker(t, false)
+	g.rule, g.boundary = ruleName, boundary
+	if rule.IsLife(ruleName) {
+		for y := 0; y < height; y++ {
+			g.World = append(g.World, util.NewBitArray(width))
+		}
+		return g
+	}
+	r, err := rule.Parse(ruleName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for y := 0; y < height; y++ {
+		g.States = append(g.States, util.NewStateArray(width, util.BitsForStates(r.States())))
+	}
+	return g
+}
+
+// TestSetRegion checks that regions are clipped to the world or wrap around the torus, however large they are, and
+// that regions with no cells in the world are refused
+func TestSetRegion(t *testing.T) {
+	tests := []struct {
+		boundary util.Boundary
+		region   stubs.RegionRequest
+		alive    int
+	}{
+		{util.DeadBorder, stubs.RegionRequest{X: 2, Y: 1, Width: 3, Height: 2, State: 1}, 6},
+		{util.DeadBorder, stubs.RegionRequest{X: -5, Y: -5, Width: 10, Height: 10, State: 1}, 25},
+		{util.Reflect, stubs.RegionRequest{X: 12, Y: 6, Width: 1 << 30, Height: 1 << 30, State: 1}, 8},
+		{util.Torus, stubs.RegionRequest{X: 14, Y: 6, Width: 4, Height: 4, State: 1}, 16},
+		{util.Torus, stubs.RegionRequest{X: -3, Y: 5, Width: 1 << 30, Height: 1 << 30, State: 1}, 128},
+	}
+	for _, test := range tests {
+		g := editBroker(t, rule.Life, test.boundary, 16, 8)
+		res := new(stubs.AliveCellsResponse)
+		if err := g.SetRegion(test.region, res); err != nil {
+			t.Errorf("%v SetRegion(%+v) failed: %v", test.boundary, test.region, err)
+			continue
+		}
+		if res.AliveCellsCount != test.alive || AliveCount(g.World) != test.alive {
+			t.Errorf("%v SetRegion(%+v) left %d cells alive, want %d", test.boundary, test.region, AliveCount(g.World), test.alive)
+		}
+	}
+
+	for _, region := range []stubs.RegionRequest{
+		{X: 0, Y: 0, Width: 0, Height: 4, State: 1},
+		{X: 0, Y: 0, Width: 4, Height: -1, State: 1},
+		{X: 16, Y: 0, Width: 4, Height: 4, State: 1},
+		{X: -4, Y: -4, Width: 4, Height: 4, State: 1},
+		{X: 0, Y: 0, Width: 4, Height: 4, State: 2},
+	} {
+		g := editBroker(t, rule.Life, util.DeadBorder, 16, 8)
+		if err := g.SetRegion(region, new(stubs.AliveCellsResponse)); err == nil {
+			t.Errorf("SetRegion(%+v) should fail", region)
+		}
+	}
+	if err := newTestBroker(t, false).SetRegion(stubs.RegionRequest{Width: 1, Height: 1}, new(stubs.AliveCellsResponse)); err == nil {
+		t.Error("SetRegion before any run should fail")
+	}
+}
+
+// TestStampPattern checks that patterns are clipped to the world or wrap around the torus, and that patterns larger
+// than the world or with no cells in it are refused
+func TestStampPattern(t *testing.T) {
+	const glider = "x = 3, y = 3\nbo$2bo$3o!"
+	tests := []struct {
+		boundary util.Boundary
+		x, y     int
+		alive    int
+	}{
+		{util.DeadBorder, 2, 1, 5},
+		{util.DeadBorder, -1, -1, 3},
+		{util.DeadBorder, 14, 6, 1},
+		{util.Torus, 14, 6, 5},
+	}
+	for _, test := range tests {
+		g := editBroker(t, rule.Life, test.boundary, 16, 8)
+		res := new(stubs.AliveCellsResponse)
+		if err := g.StampPattern(stubs.StampRequest{X: test.x, Y: test.y, RLE: glider}, res); err != nil {
+			t.Errorf("%v StampPattern at %d, %d failed: %v", test.boundary, test.x, test.y, err)
+			continue
+		}
+		if res.AliveCellsCount != test.alive || AliveCount(g.World) != test.alive {
+			t.Errorf("%v StampPattern at %d, %d left %d cells alive, want %d", test.boundary, test.x, test.y, AliveCount(g.World), test.alive)
+		}
+	}
+
+	for _, stamp := range []stubs.StampRequest{
+		{X: 16, Y: 0, RLE: glider},
+		{X: -3, Y: -3, RLE: glider},
+		{X: 0, Y: 0, RLE: "!"},
+		{X: 0, Y: 0, RLE: "x = 17, y = 1\no!"},
+		{X: 0, Y: 0, RLE: "x = 16384, y = 16384\no!"},
+		{X: 0, Y: 0, RLE: "8$o!"},
+	} {
+		g := editBroker(t, rule.Life, util.DeadBorder, 16, 8)
+		if err := g.StampPattern(stamp, new(stubs.AliveCellsResponse)); err == nil {
+			t.Errorf("StampPattern(%+v) should fail", stamp)
+		}
+	}
+}
+
+// TestEditRoundTrip checks that a pattern stamped into the world comes back out of GetRegion and of the world in
+// RLE, for Life and a rule with more states, and that ClearWorld kills every cell
+func TestEditRoundTrip(t *testing.T) {
+	tests := []struct {
+		rule, pattern string
+	}{
+		{rule.Life, "x = 3, y = 3, rule = B3/S23\nbo$2bo$3o!"},
+		{"B2/S/C3", "x = 4, y = 2, rule = B2/S/C3\nA2.B$.BA!"},
+	}
+	for _, test := range tests {
+		g := editBroker(t, test.rule, util.Torus, 16, 8)
+		pattern, err := rle.Parse(test.pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := g.StampPattern(stubs.StampRequest{X: 14, Y: 7, RLE: test.pattern}, new(stubs.AliveCellsResponse)); err != nil {
+			t.Fatalf("%s: StampPattern failed: %v", test.rule, err)
+		}
+
+		region := new(stubs.RegionResponse)
+		if err := g.GetRegion(stubs.RegionRequest{Width: 16, Height: 8}, region); err != nil {
+			t.Fatal(err)
+		}
+		world, _ := g.snapshot()
+		out, err := encodeWorld(0, test.rule, world.World, world.States, formatRLE)
+		if err != nil {
+			t.Fatal(err)
+		}
+		back, err := rle.Parse(out.RLE)
+		if err != nil {
+			t.Fatalf("%s: the world in RLE does not parse: %v", test.rule, err)
+		}
+		if back.Width != 16 || back.Height != 8 {
+			t.Errorf("%s: the world in RLE is %dx%d, want 16x8", test.rule, back.Width, back.Height)
+		}
+		for y := 0; y < 8; y++ {
+			for x := 0; x < 16; x++ {
+				// the pattern was stamped across the corner of the torus
+				want, px, py := uint8(0), (x+2)%16, (y+1)%8
+				if px < pattern.Width && py < pattern.Height {
+					want = pattern.At(px, py)
+				}
+				if back.At(x, y) != want {
+					t.Errorf("%s: cell %d, %d is %d in RLE, want %d", test.rule, x, y, back.At(x, y), want)
+				}
+				if region.Rows[y].GetBit(x) != (want != 0) {
+					t.Errorf("%s: cell %d, %d of the region is %v, want %v", test.rule, x, y, region.Rows[y].GetBit(x), want != 0)
+				}
+			}
+		}
+
+		res := new(stubs.AliveCellsResponse)
+		if err := g.ClearWorld(struct{}{}, res); err != nil || res.AliveCellsCount != 0 {
+			t.Errorf("%s: ClearWorld left %d cells alive, %v", test.rule, res.AliveCellsCount, err)
+		}
+	}
+	g := editBroker(t, rule.Life, util.Torus, 16, 8)
+	if err := g.StampPattern(stubs.StampRequest{RLE: "x = 1, y = 1\nB!"}, new(stubs.AliveCellsResponse)); err == nil {
+		t.Error("stamping a state that Life does not have should fail")
+	}
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net"
+	pb "uk.ac.bris.cs/gameoflife/proto"
+	"uk.ac.bris.cs/gameoflife/stubs"
+)
+
+// brokerServer serves the RPC methods of the broker over gRPC, converting to and from the structs of stubs
+type brokerServer struct {
+	pb.UnimplementedBrokerServer
+	g *GameOfLifeOperations
+}
+
+func (s brokerServer) Hello(_ context.Context, req *pb.Hello) (*pb.Hello, error) {
+	res := new(stubs.Hello)
+	if err := s.g.Hello(pb.ToHello(req), res); err != nil {
+		return nil, err
+	}
+	return pb.FromHello(*res), nil
+}
+
+func (s brokerServer) RunGameOfLife(_ context.Context, req *pb.Request) (*pb.Response, error) {
+	res := new(stubs.Response)
+	if err := s.g.RunGameOfLife(pb.ToRequest(req), res); err != nil {
+		return nil, err
+	}
+	return &pb.Response{NextWorld: pb.FromWorld(res.NextWorld, res.NextStates), CompletedTurns: int64(res.CompletedTurns)}, nil
+}
+
+// aliveResponse converts the responses of the methods that answer with the number of alive cells
+func aliveResponse(res *stubs.AliveCellsResponse, err error) (*pb.AliveCellsResponse, error) {
+	if err != nil {
+		return nil, err
+	}
+	return &pb.AliveCellsResponse{AliveCellsCount: int64(res.AliveCellsCount), CompletedTurns: int64(res.CompletedTurns)}, nil
+}
+
+func (s brokerServer) GetAliveCount(context.Context, *pb.Empty) (*pb.AliveCellsResponse, error) {
+	res := new(stubs.AliveCellsResponse)
+	return aliveResponse(res, s.g.GetAliveCount(struct{}{}, res))
+}
+
+func (s brokerServer) GetAliveCountAt(_ context.Context, req *pb.AliveCountAtRequest) (*pb.AliveCellsResponse, error) {
+	res := new(stubs.AliveCellsResponse)
+	return aliveResponse(res, s.g.GetAliveCountAt(stubs.AliveCountAtRequest{Turn: int(req.GetTurn())}, res))
+}
+
+func (s brokerServer) SetRegion(_ context.Context, req *pb.RegionRequest) (*pb.AliveCellsResponse, error) {
+	res := new(stubs.AliveCellsResponse)
+	return aliveResponse(res, s.g.SetRegion(pb.ToRegionRequest(req), res))
+}
+
+func (s brokerServer) StampPattern(_ context.Context, req *pb.StampRequest) (*pb.AliveCellsResponse, error) {
+	res := new(stubs.AliveCellsResponse)
+	return aliveResponse(res, s.g.StampPattern(stubs.StampRequest{X: int(req.GetX()), Y: int(req.GetY()), RLE: req.GetRle()}, res))
+}
+
+func (s brokerServer) ClearWorld(context.Context, *pb.Empty) (*pb.AliveCellsResponse, error) {
+	res := new(stubs.AliveCellsResponse)
+	return aliveResponse(res, s.g.ClearWorld(struct{}{}, res))
+}
+
+func (s brokerServer) GetCurrentWorld(context.Context, *pb.Empty) (*pb.CurrentWorldResponse, error) {
+	res := new(stubs.CurrentWorldResponse)
+	if err := s.g.GetCurrentWorld(struct{}{}, res); err != nil {
+		return nil, err
+	}
+	return &pb.CurrentWorldResponse{World: pb.FromWorld(res.World, res.States), CompletedTurns: int64(res.CompletedTurns)}, nil
+}
+
+func (s brokerServer) HaltTurns(context.Context, *pb.Empty) (*pb.Empty, error) {
+	return &pb.Empty{}, s.g.HaltTurns(struct{}{}, nil)
+}
+
+func (s brokerServer) PauseServer(context.Context, *pb.Empty) (*pb.PauseServerResponse, error) {
+	res := new(stubs.PauseServerResponse)
+	if err := s.g.PauseServer(struct{}{}, res); err != nil {
+		return nil, err
+	}
+	return &pb.PauseServerResponse{CompletedTurns: int64(res.CompletedTurns)}, nil
+}
+
+func (s brokerServer) KillClients(context.Context, *pb.Empty) (*pb.Empty, error) {
+	return &pb.Empty{}, s.g.KillClients(struct{}{}, nil)
+}
+
+func (s brokerServer) GetWorkerStats(context.Context, *pb.Empty) (*pb.WorkerStatsResponse, error) {
+	res := new(stubs.WorkerStatsResponse)
+	if err := s.g.GetWorkerStats(struct{}{}, res); err != nil {
+		return nil, err
+	}
+	return pb.FromWorkerStats(*res), nil
+}
+
+func (s brokerServer) GetRegion(_ context.Context, req *pb.RegionRequest) (*pb.RegionResponse, error) {
+	res := new(stubs.RegionResponse)
+	if err := s.g.GetRegion(pb.ToRegionRequest(req), res); err != nil {
+		return nil, err
+	}
+	return pb.FromRegionResponse(*res), nil
+}
+
+// WatchTurns sends the cells that flip within the rectangle of req after every turn, until the caller cancels or
+// falls too far behind
+func (s brokerServer) WatchTurns(req *pb.WatchRequest, stream pb.Broker_WatchTurnsServer) error {
+	w := s.g.watch(int(req.GetX()), int(req.GetY()), int(req.GetWidth()), int(req.GetHeight()))
+	defer s.g.unwatch(w)
+	for {
+		select {
+		case d, ok := <-w.diffs:
+			if !ok {
+				return status.Errorf(codes.ResourceExhausted, "fell more than %d turns behind", watchBuffer)
+			}
+			flipped := make([]*pb.Cell, len(d.flipped))
+			for i, cell := range d.flipped {
+				flipped[i] = &pb.Cell{X: int32(cell.X), Y: int32(cell.Y)}
+			}
+			if err := stream.Send(&pb.TurnDiff{CompletedTurns: int64(d.turn), Flipped: flipped, States: d.states}); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+}
+
+// serveGRPC serves the broker over gRPC on addr, alongside net/rpc
+func (g *GameOfLifeOperations) serveGRPC(addr string) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println("#GRPC ON", addr)
+	if err := newGRPCServer(g).Serve(listener); err != nil {
+		fmt.Println(err)
+	}
+}
+
+// newGRPCServer returns a gRPC server of the broker
+func newGRPCServer(g *GameOfLifeOperations) *grpc.Server {
+	server := pb.NewServer()
+	pb.RegisterBrokerServer(server, brokerServer{g: g})
+	return server
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/grpc"
+	"io"
+	"net"
+	"testing"
+	"time"
+	pb "uk.ac.bris.cs/gameoflife/proto"
+	"uk.ac.bris.cs/gameoflife/stubs"
+	"uk.ac.bris.cs/gameoflife/util"
+)
+
+// serveGRPC serves server on a loopback address and returns a client of it, and a function that stops both
+func serveGRPC(t *testing.T, server *grpc.Server) (*pb.Client, func()) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go server.Serve(listener)
+	client, err := pb.Dial(listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client, func() {
+		_ = client.Close()
+		server.Stop()
+	}
+}
+
+// testWorkerServer serves testWorker over gRPC, leaving Hello unimplemented like a worker from before it
+type testWorkerServer struct {
+	pb.UnimplementedWorkerServer
+}
+
+func (testWorkerServer) StreamStrips(stream pb.Worker_StreamStripsServer) error {
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		res := new(stubs.WorkerResponse)
+		if err := (testWorker{}).Worker(pb.ToWorkerRequest(req), res); err != nil {
+			return err
+		}
+		if err := stream.Send(pb.FromWorkerResponse(*res)); err != nil {
+			return err
+		}
+	}
+}
+
+// TestGRPCRun checks that a run, the world and edits made over gRPC are the same as when made directly
+func TestGRPCRun(t *testing.T) {
+	g := newTestBroker(t, false)
+	client, stop := serveGRPC(t, newGRPCServer(g))
+	defer stop()
+	hello, err := stubs.CallHello(client, stubs.BrokerHello, brokerHello())
+	if err != nil || !hello.Has(stubs.FeatureEdits) {
+		t.Fatalf("Hello over gRPC agreed on %+v, %v, want every feature of the broker", hello, err)
+	}
+
+	world := soup(40, 24, 5)
+	request := stubs.Request{Turns: 30, ImageWidth: 40, ImageHeight: 24, World: world, Boundary: util.KleinBottle}
+	response := new(stubs.Response)
+	if err := client.Call(stubs.RunGameOfLife, request, response); err != nil {
+		t.Fatal(err)
+	}
+	expected := referenceTurns(world, util.KleinBottle, 30)
+	if response.CompletedTurns != 30 || response.NextStates != nil {
+		t.Fatalf("the run completed %d turns with states %v, want 30 turns of Life", response.CompletedTurns, response.NextStates)
+	}
+	assertEqualWorld(t, response.NextWorld, expected, "gRPC")
+	alive := new(stubs.AliveCellsResponse)
+	if err := client.Call(stubs.GetAliveCount, struct{}{}, alive); err != nil || alive.AliveCellsCount != AliveCount(expected) {
+		t.Errorf("GetAliveCount over gRPC answered %+v, %v, want %d alive", alive, err, AliveCount(expected))
+	}
+	if err := client.Call(stubs.RunGameOfLife, stubs.Request{Rule: "B3/S23/bogus", World: world}, response); err == nil {
+		t.Error("a run with a rule that does not parse should fail over gRPC")
+	}
+
+	g = editBroker(t, "B2/S/C3", util.Torus, 16, 8)
+	client, stop = serveGRPC(t, newGRPCServer(g))
+	defer stop()
+	stamp := stubs.StampRequest{X: 3, Y: 2, RLE: "x = 4, y = 2, rule = B2/S/C3\nA2.B$.BA!"}
+	if err := client.Call(stubs.StampPattern, stamp, alive); err != nil || alive.AliveCellsCount != 4 {
+		t.Fatalf("StampPattern over gRPC answered %+v, %v, want 4 cells that are not dead", alive, err)
+	}
+	current := new(stubs.CurrentWorldResponse)
+	if err := client.Call(stubs.GetCurrentWorld, struct{}{}, current); err != nil {
+		t.Fatal(err)
+	}
+	if current.World != nil || fmt.Sprint(current.States) != fmt.Sprint(g.States) {
+		t.Errorf("the world over gRPC is %v and %v, want the states %v", current.World, current.States, g.States)
+	}
+}
+
+// TestGRPCWorkers checks that a broker calling its workers over StreamStrips ends up with the reference world, both
+// sending every row and only the active tiles, and that workers without Hello are taken to be from before it
+func TestGRPCWorkers(t *testing.T) {
+	for _, sparse := range []bool{false, true} {
+		g := newTestBroker(t, sparse)
+		for i := range g.clients {
+			server := pb.NewServer()
+			pb.RegisterWorkerServer(server, testWorkerServer{})
+			client, stop := serveGRPC(t, server)
+			defer stop()
+			g.clients[i] = client
+		}
+		if hello, err := stubs.CallHello(g.clients[0], stubs.WorkerHello, workerHello()); err != nil || hello.Version != 1 {
+			t.Fatalf("a worker without Hello agreed on %+v, %v, want Legacy", hello, err)
+		}
+		expected := referenceTurns(soup(72, 40, 6), util.Reflect, 60)
+		assertEqualWorld(t, run(t, g, soup(72, 40, 6), util.Reflect, 60), expected, fmt.Sprint("sparse ", sparse))
+	}
+}
+
+// TestWatchTurns checks that the cells flipped on each turn follow the world from turn to turn, that a watcher of a
+// rectangle only hears of the cells inside it, and that a watcher that falls behind is dropped
+func TestWatchTurns(t *testing.T) {
+	g := newTestBroker(t, true)
+	client, stop := serveGRPC(t, newGRPCServer(g))
+	defer stop()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	whole, err := client.Broker().WatchTurns(ctx, &pb.WatchRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	part, err := client.Broker().WatchTurns(ctx, &pb.WatchRequest{X: 30, Y: -4, Width: 20, Height: 12})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for deadline := time.Now().Add(10 * time.Second); ; time.Sleep(time.Millisecond) {
+		mutex.Lock()
+		watching := len(g.watchers)
+		mutex.Unlock()
+		if watching == 2 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%d of 2 watchers were started", watching)
+		}
+	}
+
+	// the run turns the world it is given, so the reference starts from another copy
+	world := soup(40, 24, 7)
+	run(t, g, soup(40, 24, 7), util.Torus, 20)
+	followed, previous := soup(40, 24, 7), world
+	for turn := 1; turn <= 20; turn++ {
+		expected := referenceTurns(world, util.Torus, turn)
+		d, err := whole.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d.CompletedTurns != int64(turn) {
+			t.Fatalf("the diff of turn %d is for turn %d", turn, d.CompletedTurns)
+		}
+		for _, cell := range d.Flipped {
+			followed[cell.Y].SetBit(int(cell.X), !followed[cell.Y].GetBit(int(cell.X)))
+		}
+		assertEqualWorld(t, followed, expected, fmt.Sprint(turn))
+
+		d, err = part.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		n := 0
+		for y := 0; y < 8; y++ {
+			for x := 30; x < 40; x++ {
+				if expected[y].GetBit(x) != previous[y].GetBit(x) {
+					n++
+				}
+			}
+		}
+		for _, cell := range d.Flipped {
+			if cell.X < 30 || cell.X >= 40 || cell.Y < 0 || cell.Y >= 8 {
+				t.Errorf("turn %d: the cell %d, %d outside the watched rectangle flipped", turn, cell.X, cell.Y)
+			}
+		}
+		if len(d.Flipped) != n {
+			t.Errorf("turn %d: %d cells flipped in the watched rectangle, want %d", turn, len(d.Flipped), n)
+		}
+		previous = expected
+	}
+
+	behind := g.watch(0, 0, 0, 0)
+	run(t, g, soup(40, 24, 7), util.Torus, watchBuffer+10)
+	received := 0
+	for range behind.diffs {
+		received++
+	}
+	mutex.Lock()
+	dropped := !g.watchers[behind]
+	mutex.Unlock()
+	if received != watchBuffer || !dropped {
+		t.Errorf("a watcher that was not read received %d turns and was dropped %v, want %d and dropped", received, dropped, watchBuffer)
+	}
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"uk.ac.bris.cs/gameoflife/hashlife"
+	"uk.ac.bris.cs/gameoflife/stubs"
+	"uk.ac.bris.cs/gameoflife/util"
+)
+
+// useEngine switches the broker to the requested engine, carrying on from the current world and turn.
+// It falls back to the step engine if HashLife cannot run on the world
+func (g *GameOfLifeOperations) useEngine(engine string) {
+	if engine == stubs.EngineHashLife {
+		if g.universe != nil {
+			return
+		}
+		if g.boundary != util.Torus || g.States != nil {
+			fmt.Println("#USING STEP ENGINE: hashlife only supports Life on the torus boundary")
+			return
+		}
+		universe, err := hashlife.New(g.World)
+		if err != nil {
+			fmt.Println("#USING STEP ENGINE:", err)
+			return
+		}
+		g.universe = universe
+		g.universeStart = g.CompletedTurns
+		fmt.Println("#USING HASHLIFE ENGINE")
+		return
+	}
+	if g.universe != nil {
+		g.World = g.universe.World()
+		g.universe = nil
+		g.activity = nil
+	}
+}
+
+// hashLifeTurns advances the HashLife engine as far towards Turns as it can in one piece of work
+func hashLifeTurns(Turns int, g *GameOfLifeOperations) {
+	g.universe.Jump(Turns - g.CompletedTurns)
+	g.CompletedTurns = g.universeStart + g.universe.Turn()
+}
+
+// currentWorld returns the latest world from whichever engine is running, must be called with the mutex
+func (g *GameOfLifeOperations) currentWorld() []util.BitArray {
+	if g.universe != nil {
+		return g.universe.World()
+	}
+	return g.World
+}
+
+// aliveCount returns the number of alive cells from whichever engine is running, must be called with the mutex
+func (g *GameOfLifeOperations) aliveCount() int {
+	if g.universe != nil {
+		return g.universe.AliveCount()
+	}
+	if g.States != nil {
+		return AliveStateCount(g.States)
+	}
+	return AliveCount(g.World)
+}
+
+// GetAliveCountAt is an RPC method, it returns the number of alive cells on any turn since HashLife was started,
+// including turns that have not been reached yet
+func (g *GameOfLifeOperations) GetAliveCountAt(req stubs.AliveCountAtRequest, res *stubs.AliveCellsResponse) (err error) {
+	mutex.Lock()
+	if g.universe == nil {
+		mutex.Unlock()
+		return errors.New("alive counts at other turns need the hashlife engine")
+	}
+	start := g.universeStart
+	if req.Turn < start {
+		mutex.Unlock()
+		return fmt.Errorf("hashlife was started on turn %d", start)
+	}
+	// the clone is advanced without the mutex, as the turn asked for may be a long way off
+	at := g.universe.Clone()
+	mutex.Unlock()
+	if err := at.Seek(req.Turn - start); err != nil {
+		return err
+	}
+	res.AliveCellsCount = at.AliveCount()
+	res.CompletedTurns = req.Turn
+	return
+}
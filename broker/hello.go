@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"uk.ac.bris.cs/gameoflife/rule"
+	"uk.ac.bris.cs/gameoflife/stubs"
+	"uk.ac.bris.cs/gameoflife/util"
+)
+
+// workerHello is what the broker can ask of its workers
+func workerHello() stubs.Hello {
+	return stubs.Hello{
+		Version:    stubs.ProtocolVersion,
+		MinVersion: stubs.MinProtocolVersion,
+		Rules:      rule.Families,
+		Encodings:  []string{stubs.EncodingBits, stubs.EncodingStates},
+		Features:   stubs.WorkerFeatures,
+	}
+}
+
+// brokerHello is what the broker supports with workers that support everything
+func brokerHello() stubs.Hello {
+	hello := workerHello()
+	hello.Features = append([]string{stubs.FeatureHashLife, stubs.FeatureEdits, stubs.FeatureRegions,
+		stubs.FeatureStats, stubs.FeatureAliveAt}, stubs.WorkerFeatures...)
+	return hello
+}
+
+// greetWorkers makes the Hello call to each worker. Workers that the broker cannot talk to are disconnected, and
+// anything that not every worker supports is left out of g.hello. Workers from before the Hello call only run Life
+// densely on the torus, so sparse turns are turned off for them
+func (g *GameOfLifeOperations) greetWorkers(addresses []string) {
+	g.hello = brokerHello()
+	for i, client := range g.clients {
+		if client == nil {
+			continue
+		}
+		agreed, err := stubs.CallHello(client, stubs.WorkerHello, workerHello())
+		if err != nil {
+			fmt.Println("#REFUSED WORKER", addresses[i]+":", err)
+			if err := client.Close(); err != nil {
+				fmt.Println(err)
+			}
+			g.clients[i] = nil
+			continue
+		}
+		if agreed.Version < stubs.ProtocolVersion {
+			fmt.Println("#OLD WORKER", addresses[i], "VERSION", agreed.Version)
+		}
+		g.hello.Rules = stubs.Intersect(g.hello.Rules, agreed.Rules)
+		g.hello.Encodings = stubs.Intersect(g.hello.Encodings, agreed.Encodings)
+		for _, feature := range stubs.WorkerFeatures {
+			if !agreed.Has(feature) {
+				g.hello.Features = without(g.hello.Features, feature)
+			}
+		}
+	}
+	if g.sparse && !g.hello.Has(stubs.FeatureSparse) {
+		fmt.Println("#SPARSE TURNS OFF: not every worker supports them")
+		g.sparse = false
+	}
+}
+
+// without returns list with every s left out
+func without(list []string, s string) []string {
+	kept := make([]string, 0, len(list))
+	for _, l := range list {
+		if l != s {
+			kept = append(kept, l)
+		}
+	}
+	return kept
+}
+
+// Hello is an RPC that answers the controller with what the broker and all of its workers support, refusing a
+// controller it cannot talk to
+func (g *GameOfLifeOperations) Hello(req stubs.Hello, res *stubs.Hello) error {
+	if _, err := stubs.Negotiate(g.hello, req); err != nil {
+		fmt.Println("#REFUSED CONTROLLER:", err)
+		return err
+	}
+	*res = g.hello
+	return nil
+}
+
+// workers returns how many workers the broker is connected to, only to be called with the mutex
+func (g *GameOfLifeOperations) workers() int {
+	n := 0
+	for _, client := range g.clients {
+		if client != nil {
+			n++
+		}
+	}
+	return n
+}
+
+// supports returns an error if a new world in req needs something that the broker or its workers do not support
+func (g *GameOfLifeOperations) supports(req stubs.Request) error {
+	r, err := rule.Parse(req.Rule)
+	if err != nil {
+		return err
+	}
+	if family := rule.Family(r); !g.hello.HasRule(family) {
+		return fmt.Errorf("the workers cannot run %s rules such as %v", family, r)
+	}
+	if req.States != nil && !g.hello.HasEncoding(stubs.EncodingStates) {
+		return fmt.Errorf("the workers can only be sent worlds of Life")
+	}
+	if req.Boundary != util.Torus && !g.hello.Has(stubs.FeatureBoundaries) {
+		return fmt.Errorf("the workers only support the torus boundary")
+	}
+	return nil
+}
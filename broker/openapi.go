@@ -0,0 +1,125 @@
+package main
+
+// openAPI describes the JSON API served under /api/, in OpenAPI 3
+const openAPI = `{
+  "openapi": "3.0.3",
+  "info": {
+    "title": "Game of Life broker",
+    "version": "1.0.0",
+    "description": "Starts and follows runs of the game of life on the broker, making the same calls as the controller."
+  },
+  "paths": {
+    "/api/run": {
+      "post": {
+        "summary": "Start a run",
+        "description": "Runs a world for a number of turns. Only one run can happen at a time.",
+        "requestBody": {
+          "required": true,
+          "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Run"}}}
+        },
+        "responses": {
+          "200": {"description": "The final world, when wait is true", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/World"}}}},
+          "202": {"description": "The run has started", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/State"}}}},
+          "400": {"$ref": "#/components/responses/Error"},
+          "409": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/api/state": {
+      "get": {
+        "summary": "Whether a run is happening and whether it is paused",
+        "responses": {"200": {"description": "The state", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/State"}}}}}
+      }
+    },
+    "/api/alive": {
+      "get": {
+        "summary": "The number of alive cells",
+        "responses": {"200": {"description": "The count", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Count"}}}}}
+      }
+    },
+    "/api/world": {
+      "get": {
+        "summary": "The current world",
+        "parameters": [{"name": "format", "in": "query", "schema": {"$ref": "#/components/schemas/Format"}}],
+        "responses": {
+          "200": {"description": "The world", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/World"}}}},
+          "400": {"$ref": "#/components/responses/Error"},
+          "409": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/api/pause": {
+      "post": {
+        "summary": "Pause the run, or resume it if it is paused",
+        "responses": {"200": {"description": "The new state", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/State"}}}}}
+      }
+    },
+    "/api/halt": {
+      "post": {
+        "summary": "Stop the run, keeping the world",
+        "responses": {"200": {"description": "The new state", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/State"}}}}}
+      }
+    },
+    "/api/kill": {
+      "post": {
+        "summary": "Stop the run and shut down the workers and broker",
+        "responses": {"200": {"description": "The new state", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/State"}}}}}
+      }
+    }
+  },
+  "components": {
+    "responses": {
+      "Error": {
+        "description": "The request could not be carried out",
+        "content": {"application/json": {"schema": {"type": "object", "properties": {"error": {"type": "string"}}}}}
+      }
+    },
+    "schemas": {
+      "Format": {
+        "type": "string",
+        "enum": ["rle", "bits"],
+        "default": "rle",
+        "description": "rle is a pattern in RLE. bits is the rows of the world in base64, each row starting on a new byte with the leftmost cell in the lowest bit."
+      },
+      "Run": {
+        "type": "object",
+        "properties": {
+          "width": {"type": "integer", "description": "Defaults to the width of the rle pattern, which for Life is rounded up to a multiple of 8 as Life worlds are stored in whole bytes"},
+          "height": {"type": "integer", "description": "Defaults to the height of the rle pattern, and must be at least the number of workers"},
+          "turns": {"type": "integer", "description": "The turn to stop on, counted from the start of the world when resuming"},
+          "rule": {"type": "string", "description": "Defaults to the rule in the header of the rle pattern, or B3/S23, and is the rule of the current world when resuming"},
+          "boundary": {"type": "string", "enum": ["torus", "dead", "reflect", "klein", "cross"], "default": "torus"},
+          "engine": {"type": "string", "enum": ["step", "hashlife"], "default": "step"},
+          "threads": {"type": "integer", "minimum": 0, "maximum": 256, "description": "Goroutines for each worker, 0 leaves it to the workers"},
+          "rle": {"type": "string", "description": "The world as a pattern placed at the top left"},
+          "bits": {"type": "string", "description": "The world in the bits format"},
+          "resume": {"type": "boolean", "description": "Carry on from the current world instead of rle or bits"},
+          "wait": {"type": "boolean", "description": "Answer with the final world once the turns are done"},
+          "format": {"$ref": "#/components/schemas/Format"}
+        },
+        "required": ["turns"]
+      },
+      "World": {
+        "type": "object",
+        "properties": {
+          "turn": {"type": "integer"},
+          "width": {"type": "integer"},
+          "height": {"type": "integer"},
+          "alive": {"type": "integer"},
+          "rule": {"type": "string"},
+          "rle": {"type": "string"},
+          "bits": {"type": "string"}
+        }
+      },
+      "Count": {
+        "type": "object",
+        "properties": {"turn": {"type": "integer"}, "alive": {"type": "integer"}}
+      },
+      "State": {
+        "type": "object",
+        "properties": {"turn": {"type": "integer"}, "running": {"type": "boolean"}, "paused": {"type": "boolean"}}
+      }
+    }
+  }
+}
+`
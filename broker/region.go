@@ -0,0 +1,60 @@
+package main
+
+import (
+	"errors"
+	"uk.ac.bris.cs/gameoflife/stubs"
+	"uk.ac.bris.cs/gameoflife/util"
+)
+
+// GetRegion is an RPC method, it returns the cells of a rectangle of the current world that are not dead, so that
+// a window showing part of a large world only needs that part. The rectangle is clipped to the world and widened
+// to whole bytes
+func (g *GameOfLifeOperations) GetRegion(req stubs.RegionRequest, res *stubs.RegionResponse) (err error) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	world := g.currentWorld()
+	width, height := 0, len(world)
+	if g.States != nil {
+		height = len(g.States)
+		if height > 0 {
+			width = g.States[0].Len()
+		}
+	} else if height > 0 {
+		width = world[0].Len()
+	}
+	if width == 0 || height == 0 {
+		return errors.New("there is no world until the game of life has been run")
+	}
+
+	x0, y0, x1, y1 := req.X, req.Y, req.X+req.Width, req.Y+req.Height
+	if x0 < 0 {
+		x0 = 0
+	}
+	if y0 < 0 {
+		y0 = 0
+	}
+	x0 &^= 7
+	x1 = (x1 + 7) &^ 7
+	if x1 > width {
+		x1 = width
+	}
+	if y1 > height {
+		y1 = height
+	}
+
+	res.X, res.Y = x0, y0
+	res.CompletedTurns = g.CompletedTurns
+	res.Rows = make([]util.BitArray, 0)
+	for y := y0; y < y1 && x0 < x1; y++ {
+		row := util.NewBitArray(x1 - x0)
+		if g.States != nil {
+			for x := x0; x < x1; x++ {
+				row.SetBit(x-x0, g.States[y].Get(x) != 0)
+			}
+		} else {
+			copy(row, world[y][x0/8:x1/8])
+		}
+		res.Rows = append(res.Rows, row)
+	}
+	return
+}
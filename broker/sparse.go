@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"time"
+	"uk.ac.bris.cs/gameoflife/stubs"
+	"uk.ac.bris.cs/gameoflife/util"
+)
+
+// makeSparseWorkerCall asks a worker to recompute the active tiles of its strip and returns the tiles that changed
+func makeSparseWorkerCall(request stubs.WorkerRequest, client stubs.Caller, resultChannel chan []util.Tile, elapsed *time.Duration) {
+	var workerResponse stubs.WorkerResponse
+	start := time.Now()
+	if err := client.Call(stubs.Worker, request, &workerResponse); err != nil {
+		fmt.Println("RPC call error:", err)
+	} else {
+		*elapsed = time.Since(start)
+	}
+	resultChannel <- workerResponse.Tiles
+}
+
+// sparseTurn carries out a single turn, only sending the workers the tiles near a change from two turns ago
+func sparseTurn(Width int, Height int, g *GameOfLifeOperations) {
+	scale := g.scale
+	active := g.activity.Active()
+	elapsed := make([]time.Duration, stubs.Threads)
+	cells := make([]int, stubs.Threads)
+
+	workerResponses := make([]chan []util.Tile, stubs.Threads)
+	startY, endY := 0, 0 //inclusive, exclusive
+	for i := range workerResponses {
+		endY = startY + scale[i]
+		tiles := g.activity.StripTiles(active, startY, endY)
+		if len(tiles) > 0 {
+			// only the active tiles and the cells around them are sent, not the rows they are in
+			request := stubs.WorkerRequest{
+				Scale:       scale[i],
+				Threads:     g.threads,
+				WorldWidth:  Width,
+				Sparse:      true,
+				StartY:      startY,
+				ActiveTiles: tiles,
+				Halos:       util.Halos(g.World, g.boundary, tiles, startY, endY),
+				Boundary:    g.boundary,
+			}
+			cells[i] = len(tiles) * util.TileSize * util.TileSize
+			workerResponses[i] = make(chan []util.Tile)
+			go makeSparseWorkerCall(request, g.clients[i], workerResponses[i], &elapsed[i])
+		}
+		startY = endY
+	}
+
+	// every worker has to respond before the world can be written to, as the requests share its rows
+	changed := make([]util.Tile, 0)
+	for i, ch := range workerResponses {
+		if ch == nil {
+			continue
+		}
+		changed = append(changed, <-ch...)
+		g.timings.record(i, (cells[i]+Width-1)/Width, elapsed[i])
+	}
+	g.activity.Advance(g.World, active, changed)
+}
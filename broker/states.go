@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"time"
+	"uk.ac.bris.cs/gameoflife/stubs"
+	"uk.ac.bris.cs/gameoflife/util"
+)
+
+// AliveStateCount counts the cells that are not dead, including those that are dying
+func AliveStateCount(states []util.StateArray) int {
+	count := 0
+	for _, row := range states {
+		for x := 0; x < row.Len(); x++ {
+			if row.Get(x) != 0 {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// makeStateWorkerCall asks a worker to apply a rule other than Life to its strip and returns the next state of the strip
+func makeStateWorkerCall(request stubs.WorkerRequest, client stubs.Caller, resultChannel chan []util.StateArray, elapsed *time.Duration) {
+	var workerResponse stubs.WorkerResponse
+	start := time.Now()
+	if err := client.Call(stubs.Worker, request, &workerResponse); err != nil {
+		fmt.Println("RPC call error:", err)
+	} else {
+		*elapsed = time.Since(start)
+	}
+	resultChannel <- workerResponse.OutStates
+}
+
+// stateTurn carries out a single turn of a rule other than Life, where each cell has one of several states. Each
+// strip is sent with as many ghost rows as the radius of the rule
+func stateTurn(Width int, Height int, g *GameOfLifeOperations) {
+	scale := g.scale
+	elapsed := make([]time.Duration, stubs.Threads)
+	nextStates := make([]util.StateArray, 0)
+
+	workerResponses := make([]chan []util.StateArray, stubs.Threads)
+	startY, endY := 0, 0 //inclusive, exclusive
+	for i := range workerResponses {
+		endY = startY + scale[i]
+		inStates := make([]util.StateArray, 0)
+		for j := startY - g.radius; j < endY+g.radius; j++ {
+			inStates = append(inStates, g.boundary.StateRow(g.States, j))
+		}
+		west, east := g.boundary.StateEdges(g.States, startY-g.radius, len(inStates), g.radius)
+		request := stubs.WorkerRequest{
+			Scale:      scale[i],
+			Threads:    g.threads,
+			WorldWidth: Width,
+			Boundary:   g.boundary,
+			Rule:       g.rule,
+			InStates:   inStates,
+			WestStates: west,
+			EastStates: east,
+		}
+		workerResponses[i] = make(chan []util.StateArray)
+		go makeStateWorkerCall(request, g.clients[i], workerResponses[i], &elapsed[i])
+		startY = endY
+	}
+
+	for i, ch := range workerResponses {
+		nextStates = append(nextStates, <-ch...)
+		g.timings.record(i, scale[i], elapsed[i])
+	}
+	g.States = nextStates
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"uk.ac.bris.cs/gameoflife/util"
+)
+
+// watchBuffer is how many turns a watcher may fall behind before it is dropped, so that a slow watcher never holds
+// up the turns
+const watchBuffer = 64
+
+// turnDiff holds the cells that flipped on a turn, or since the last turn HashLife jumped to, with their new states
+// in the same order for rules other than Life
+type turnDiff struct {
+	turn    int
+	flipped []util.Cell
+	states  []uint8
+}
+
+// watcher receives the cells that flip within a rectangle of the world, which is the whole world when its width or
+// height is not positive. diffs is closed if it falls behind
+type watcher struct {
+	x, y, width, height int
+	diffs               chan turnDiff
+}
+
+// watchedWorld is a copy of the world from before a turn, to find the cells that the turn flips
+type watchedWorld struct {
+	world  []util.BitArray
+	states []util.StateArray
+}
+
+// size returns the width and height of the world
+func (w watchedWorld) size() (int, int) {
+	switch {
+	case len(w.states) > 0:
+		return w.states[0].Len(), len(w.states)
+	case len(w.world) > 0:
+		return w.world[0].Len(), len(w.world)
+	}
+	return 0, 0
+}
+
+// watch starts sending the cells that flip within a rectangle of the world to the returned watcher
+func (g *GameOfLifeOperations) watch(x, y, width, height int) *watcher {
+	w := &watcher{x: x, y: y, width: width, height: height, diffs: make(chan turnDiff, watchBuffer)}
+	mutex.Lock()
+	defer mutex.Unlock()
+	if g.watchers == nil {
+		g.watchers = make(map[*watcher]bool)
+	}
+	g.watchers[w] = true
+	return w
+}
+
+// unwatch stops sending to w
+func (g *GameOfLifeOperations) unwatch(w *watcher) {
+	mutex.Lock()
+	delete(g.watchers, w)
+	mutex.Unlock()
+}
+
+// beforeTurn returns a copy of the world to compare the turn against, or nil when nobody is watching. It must be
+// called with the mutex
+func (g *GameOfLifeOperations) beforeTurn() *watchedWorld {
+	if len(g.watchers) == 0 {
+		return nil
+	}
+	before := new(watchedWorld)
+	for _, row := range g.currentWorld() {
+		before.world = append(before.world, append(util.BitArray(nil), row...))
+	}
+	for _, row := range g.States {
+		before.states = append(before.states, util.StateArray{Bits: row.Bits, Data: append([]uint8(nil), row.Data...)})
+	}
+	return before
+}
+
+// publish sends the cells that flipped since before to each watcher, dropping any that have fallen behind. It must
+// be called with the mutex
+func (g *GameOfLifeOperations) publish(before *watchedWorld) {
+	if before == nil {
+		return
+	}
+	after := watchedWorld{world: g.currentWorld(), states: g.States}
+	for w := range g.watchers {
+		select {
+		case w.diffs <- w.diff(before, after, g.CompletedTurns):
+		default:
+			close(w.diffs)
+			delete(g.watchers, w)
+		}
+	}
+}
+
+// diff returns the cells of the rectangle of w that are different in after than in before
+func (w *watcher) diff(before *watchedWorld, after watchedWorld, turn int) turnDiff {
+	width, height := after.size()
+	x0, y0, x1, y1 := 0, 0, width, height
+	if w.width > 0 && w.height > 0 {
+		x0, x1 = clip(w.x, w.width, width, false)
+		y0, y1 = clip(w.y, w.height, height, false)
+	}
+
+	d := turnDiff{turn: turn}
+	for y := y0; y < y1; y++ {
+		for x := x0; x < x1; x++ {
+			if after.states != nil {
+				if state := after.states[y].Get(x); state != before.states[y].Get(x) {
+					d.flipped = append(d.flipped, util.Cell{X: x, Y: y})
+					d.states = append(d.states, state)
+				}
+			} else if after.world[y].GetBit(x) != before.world[y].GetBit(x) {
+				d.flipped = append(d.flipped, util.Cell{X: x, Y: y})
+			}
+		}
+	}
+	return d
+}
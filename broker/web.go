@@ -0,0 +1,235 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+	"uk.ac.bris.cs/gameoflife/rule"
+	"uk.ac.bris.cs/gameoflife/stubs"
+)
+
+// webFrameSize is the largest width or height of the frames streamed to the dashboard, larger worlds are scaled
+// down with a frame cell alive if any of the cells it covers is
+const webFrameSize = 512
+
+// webFrame is one frame of the dashboard stream, with the stats shown next to it. Cells holds the rows of the
+// frame packed into bits like util.BitArray, compressed with zlib and encoded in base64
+type webFrame struct {
+	Turn        int     `json:"turn"`
+	Alive       int     `json:"alive"`
+	Paused      bool    `json:"paused"`
+	Workers     int     `json:"workers"`
+	Rate        float64 `json:"rate"`
+	Width       int     `json:"width"`
+	Height      int     `json:"height"`
+	FrameWidth  int     `json:"frameWidth"`
+	FrameHeight int     `json:"frameHeight"`
+	Cells       string  `json:"cells"`
+}
+
+// frame returns the current world scaled down to at most webFrameSize cells a side, with its stats, or nil if
+// the game of life has not been run yet
+func (g *GameOfLifeOperations) frame() *webFrame {
+	mutex.Lock()
+	defer mutex.Unlock()
+	world := g.currentWorld()
+	width, height := 0, len(world)
+	if g.States != nil {
+		height = len(g.States)
+		if height > 0 {
+			width = g.States[0].Len()
+		}
+	} else if height > 0 {
+		width = world[0].Len()
+	}
+	if width == 0 || height == 0 {
+		return nil
+	}
+
+	scale := 1
+	for width > webFrameSize*scale || height > webFrameSize*scale {
+		scale++
+	}
+	f := &webFrame{Turn: g.CompletedTurns, Alive: g.aliveCount(), Paused: g.pause, Width: width, Height: height}
+	f.FrameWidth, f.FrameHeight = (width+scale-1)/scale, (height+scale-1)/scale
+	f.Workers = g.workers()
+	rowBytes := (f.FrameWidth + 7) / 8
+	cells := make([]byte, rowBytes*f.FrameHeight)
+	set := func(x, y int) {
+		x, y = x/scale, y/scale
+		cells[y*rowBytes+x/8] |= 1 << uint(x%8)
+	}
+	for y := 0; y < height; y++ {
+		if g.States != nil {
+			for x := 0; x < width; x++ {
+				if g.States[y].Get(x) != 0 {
+					set(x, y)
+				}
+			}
+			continue
+		}
+		// whole bytes of dead cells are skipped, so that sparse worlds are quick to scale down
+		for i, b := range world[y] {
+			for bit := uint(0); b != 0 && bit < 8; bit++ {
+				if b&(1<<bit) != 0 {
+					set(8*i+int(bit), y)
+				}
+			}
+		}
+	}
+
+	var compressed bytes.Buffer
+	z := zlib.NewWriter(&compressed)
+	if _, err := z.Write(cells); err != nil {
+		fmt.Println(err)
+	}
+	if err := z.Close(); err != nil {
+		fmt.Println(err)
+	}
+	f.Cells = base64.StdEncoding.EncodeToString(compressed.Bytes())
+	return f
+}
+
+// streamFrames sends a frame to the dashboard every interval as server-sent events until it disconnects
+func (g *GameOfLifeOperations) streamFrames(w http.ResponseWriter, r *http.Request, interval time.Duration) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	lastTurn, lastTime := -1, time.Now()
+	for {
+		if f := g.frame(); f != nil {
+			now := time.Now()
+			if lastTurn >= 0 && f.Turn >= lastTurn {
+				f.Rate = float64(f.Turn-lastTurn) / now.Sub(lastTime).Seconds()
+			}
+			lastTurn, lastTime = f.Turn, now
+			data, err := json.Marshal(f)
+			if err != nil {
+				fmt.Println(err)
+				return
+			}
+			if _, err := fmt.Fprintf(w, "data: %s\n\n", data); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// writeWorld sends the current world as a PGM image, the same as the controller saves when s is pressed
+func (g *GameOfLifeOperations) writeWorld(w http.ResponseWriter) {
+	// the rule and the world are taken together, so a run started meanwhile cannot give one without the other
+	world, ruleName := g.snapshot()
+	width, height := 0, len(world.World)
+	if world.States != nil {
+		height = len(world.States)
+		if height > 0 {
+			width = world.States[0].Len()
+		}
+	} else if height > 0 {
+		width = world.World[0].Len()
+	}
+	if width == 0 || height == 0 {
+		http.Error(w, "there is no world until the game of life has been run", http.StatusConflict)
+		return
+	}
+	r, err := rule.Parse(ruleName)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/x-portable-graymap")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%dx%dx%d.pgm\"", width, height, world.CompletedTurns))
+	out := bufio.NewWriter(w)
+	_, _ = fmt.Fprintf(out, "P5\n%d %d\n255\n", width, height)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			state := uint8(0)
+			if world.States != nil {
+				state = world.States[y].Get(x)
+			} else if world.World[y].GetBit(x) == stubs.Alive {
+				state = 1
+			}
+			_ = out.WriteByte(rule.Grey(r, state))
+		}
+	}
+	if err := out.Flush(); err != nil {
+		fmt.Println(err)
+	}
+}
+
+// control returns a handler for a dashboard button that calls op, one of the RPC methods taking no arguments
+func control(op func() error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "use POST", http.StatusMethodNotAllowed)
+			return
+		}
+		if err := op(); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// dashboard returns the handler of the web dashboard and the JSON API, streaming a frame every interval. The buttons
+// of the dashboard pause and resume, save, halt and kill the same way as the p, s, q and k keys of the controller
+func (g *GameOfLifeOperations) dashboard(interval time.Duration) *http.ServeMux {
+	var empty struct{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = fmt.Fprint(w, dashboardPage)
+	})
+	mux.HandleFunc("/frames", func(w http.ResponseWriter, r *http.Request) {
+		g.streamFrames(w, r, interval)
+	})
+	mux.HandleFunc("/world.pgm", func(w http.ResponseWriter, r *http.Request) {
+		g.writeWorld(w)
+	})
+	mux.HandleFunc("/pause", control(func() error {
+		return g.PauseServer(empty, new(stubs.PauseServerResponse))
+	}))
+	mux.HandleFunc("/halt", control(func() error {
+		return g.HaltTurns(empty, &empty)
+	}))
+	mux.HandleFunc("/kill", control(func() error {
+		// the same as the k key of the controller, the workers are killed once the turns stop
+		if err := g.KillClients(empty, &empty); err != nil {
+			return err
+		}
+		return g.HaltTurns(empty, &empty)
+	}))
+	g.registerAPI(mux)
+	return mux
+}
+
+// serveDashboard serves the web dashboard and the JSON API on addr
+func (g *GameOfLifeOperations) serveDashboard(addr string, interval time.Duration) {
+	fmt.Println("#DASHBOARD ON", addr)
+	if err := http.ListenAndServe(addr, g.dashboard(interval)); err != nil {
+		fmt.Println(err)
+	}
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"uk.ac.bris.cs/gameoflife/pnm"
+	"uk.ac.bris.cs/gameoflife/util"
+)
+
+// dashboardBroker returns a broker that has run a 32x16 world for 7 turns, and a test server of its dashboard
+func dashboardBroker(t *testing.T) (*GameOfLifeOperations, []util.BitArray, *httptest.Server) {
+	g := newTestBroker(t, false)
+	world := soup(32, 16, 4)
+	g.World, g.CompletedTurns = world, 7
+	return g, world, httptest.NewServer(g.dashboard(10 * time.Millisecond))
+}
+
+// TestFrames checks that the first frame streamed to the dashboard is the current world with its stats
+func TestFrames(t *testing.T) {
+	_, world, server := dashboardBroker(t)
+	defer server.Close()
+	res, err := http.Get(server.URL + "/frames")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if contentType := res.Header.Get("Content-Type"); contentType != "text/event-stream" {
+		t.Errorf("the frames are sent as %s, want text/event-stream", contentType)
+	}
+
+	var f webFrame
+	lines := bufio.NewScanner(res.Body)
+	lines.Buffer(nil, 1<<20)
+	for lines.Scan() {
+		if data := strings.TrimPrefix(lines.Text(), "data: "); data != lines.Text() {
+			if err := json.Unmarshal([]byte(data), &f); err != nil {
+				t.Fatal(err)
+			}
+			break
+		}
+	}
+	if f.Turn != 7 || f.Alive != AliveCount(world) || f.Width != 32 || f.Height != 16 || f.FrameWidth != 32 || f.FrameHeight != 16 {
+		t.Fatalf("the first frame is turn %d with %d alive at %dx%d in %dx%d, want turn 7 with %d alive at 32x16 in 32x16",
+			f.Turn, f.Alive, f.Width, f.Height, f.FrameWidth, f.FrameHeight, AliveCount(world))
+	}
+	compressed, err := base64.StdEncoding.DecodeString(f.Cells)
+	if err != nil {
+		t.Fatal(err)
+	}
+	z, err := zlib.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cells, err := ioutil.ReadAll(z)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := base64.StdEncoding.EncodeToString(cells), packBits(world); got != want {
+		t.Errorf("the cells of the frame are %s, want %s", got, want)
+	}
+}
+
+// TestWorldImage checks that the world is saved as a PGM image of its alive cells
+func TestWorldImage(t *testing.T) {
+	_, world, server := dashboardBroker(t)
+	defer server.Close()
+	res, err := http.Get(server.URL + "/world.pgm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	img, err := pnm.Decode(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if img.Width != 32 || img.Height != 16 {
+		t.Fatalf("the image is %dx%d, want 32x16", img.Width, img.Height)
+	}
+	for y := range world {
+		for x := 0; x < 32; x++ {
+			if (img.At(x, y) != 0) != world[y].GetBit(x) {
+				t.Errorf("pixel %d, %d is %d, want the cell %v", x, y, img.At(x, y), world[y].GetBit(x))
+			}
+		}
+	}
+
+	empty := httptest.NewServer(newTestBroker(t, false).dashboard(time.Second))
+	defer empty.Close()
+	res, err = http.Get(empty.URL + "/world.pgm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = res.Body.Close()
+	if res.StatusCode != http.StatusConflict {
+		t.Errorf("the world before any run answered %d, want %d", res.StatusCode, http.StatusConflict)
+	}
+}
+
+// TestControls checks that the buttons of the dashboard pause, halt and kill, and only accept POST
+func TestControls(t *testing.T) {
+	g, _, server := dashboardBroker(t)
+	defer server.Close()
+	post := func(path string) int {
+		res, err := http.Post(server.URL+path, "", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = res.Body.Close()
+		return res.StatusCode
+	}
+
+	for _, paused := range []bool{true, false} {
+		if status := post("/pause"); status != http.StatusNoContent || g.state().Paused != paused {
+			t.Errorf("POST /pause answered %d and left paused %v, want %d and %v", status, g.state().Paused, http.StatusNoContent, paused)
+		}
+	}
+	if status := post("/halt"); status != http.StatusNoContent {
+		t.Errorf("POST /halt answered %d, want %d", status, http.StatusNoContent)
+	}
+	mutex.Lock()
+	halted, killed := g.haltTurns, g.killClients
+	mutex.Unlock()
+	if !halted || killed {
+		t.Errorf("POST /halt left halted %v and killed %v, want only halted", halted, killed)
+	}
+	if status := post("/kill"); status != http.StatusNoContent {
+		t.Errorf("POST /kill answered %d, want %d", status, http.StatusNoContent)
+	}
+	mutex.Lock()
+	killed = g.killClients
+	mutex.Unlock()
+	if !killed {
+		t.Error("POST /kill did not kill the workers")
+	}
+
+	for _, path := range []string{"/pause", "/halt", "/kill"} {
+		res, err := http.Get(server.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = res.Body.Close()
+		if res.StatusCode != http.StatusMethodNotAllowed {
+			t.Errorf("GET %s answered %d, want %d", path, res.StatusCode, http.StatusMethodNotAllowed)
+		}
+	}
+}
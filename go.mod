@@ -1,8 +1,18 @@
 module uk.ac.bris.cs/gameoflife
 
-go 1.12
+go 1.21
 
 require (
 	github.com/veandco/go-sdl2 v0.4.4
+	google.golang.org/grpc v1.59.0
+	google.golang.org/protobuf v1.31.0
+)
+
+require (
+	github.com/golang/protobuf v1.5.3 // indirect
+	golang.org/x/net v0.19.0 // indirect
 	golang.org/x/perf v0.0.0-20231127181059-b53752263861 // indirect
+	golang.org/x/sys v0.15.0 // indirect
+	golang.org/x/text v0.14.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20230822172742-b8732ec3820d // indirect
 )
@@ -1,11 +1,12 @@
 package gol
 
 import (
+	"flag"
 	"fmt"
-	"net/rpc"
-	"os"
 	"strconv"
 	"time"
+	pb "uk.ac.bris.cs/gameoflife/proto"
+	"uk.ac.bris.cs/gameoflife/rule"
 	"uk.ac.bris.cs/gameoflife/stubs"
 	"uk.ac.bris.cs/gameoflife/util"
 )
@@ -13,23 +14,19 @@ import (
 type distributorChannels struct {
 	events     chan<- Event
 	ioCommand  chan<- ioCommand
-	ioIdle     <-chan bool
+	ioIdle     <-chan error
 	ioFilename chan<- string
-	ioOutput   chan<- uint8
-	ioInput    <-chan uint8
+	ioOutput   chan<- ioImage
+	ioInput    <-chan ioImage
 }
 
 /*
-outputWorld sends the image out byte by byte via the appropriate channels
+outputWorld sends the whole world to the io goroutine to be written out
 */
-func outputWorld(height, width, turn int, world []util.BitArray, filename string, c distributorChannels) {
+func outputWorld(p Params, turn int, world []util.BitArray, filename string, c distributorChannels) {
 	c.ioCommand <- ioOutput
-	c.ioFilename <- fmt.Sprintf("%sx%d", filename, turn)
-	for i := 0; i < height; i++ { // each row
-		for j := 0; j < width; j++ { //each column
-			c.ioOutput <- world[i].GetBitToUint8(j)
-		}
-	}
+	c.ioFilename <- p.outputName(strconv.Itoa(turn))
+	c.ioOutput <- ioImage{world: world}
 	c.events <- ImageOutputComplete{CompletedTurns: turn, Filename: filename}
 }
 
@@ -56,7 +53,7 @@ func makeWorld(height, width int) []util.BitArray {
 }
 
 // getCurrentWorld makes an RPC call to get the last fully updated world, with the turn number of that world
-func getCurrentWorld(client *rpc.Client) *stubs.CurrentWorldResponse {
+func getCurrentWorld(client stubs.Caller) *stubs.CurrentWorldResponse {
 	worldResponse := new(stubs.CurrentWorldResponse)
 	if err := client.Call(stubs.GetCurrentWorld, struct{}{}, worldResponse); err != nil {
 		fmt.Println(err)
@@ -65,7 +62,7 @@ func getCurrentWorld(client *rpc.Client) *stubs.CurrentWorldResponse {
 }
 
 // regularAliveCount makes an RPC call to the server to retrieve the alive cell count and the turn number and passes this to events
-func regularAliveCount(client *rpc.Client, c distributorChannels) {
+func regularAliveCount(client stubs.Caller, c distributorChannels) {
 	response := new(stubs.AliveCellsResponse)
 	if err := client.Call(stubs.GetAliveCount, struct{}{}, response); err != nil {
 		fmt.Println(err)
@@ -73,16 +70,27 @@ func regularAliveCount(client *rpc.Client, c distributorChannels) {
 	c.events <- AliveCellsCount{CellsCount: response.AliveCellsCount, CompletedTurns: response.CompletedTurns}
 }
 
+// workersConnected makes an RPC call to find how many workers the broker is connected to and passes this to events
+func workersConnected(client stubs.Caller, c distributorChannels) {
+	response := new(stubs.WorkerStatsResponse)
+	if err := client.Call(stubs.GetWorkerStats, struct{}{}, response); err != nil {
+		fmt.Println(err)
+		return
+	}
+	c.events <- WorkersConnected{CompletedTurns: response.CompletedTurns, Workers: response.Workers}
+}
+
 // haltTurns stops the broker running the game of life until runGameOfLife is called again
-func haltTurns(client *rpc.Client) {
+func haltTurns(client stubs.Caller) {
 	haltServerResponse := new(struct{})
 	if err := client.Call(stubs.HaltTurns, struct{}{}, haltServerResponse); err != nil {
 		fmt.Println(err)
 	}
 }
 
-// handlePause blocks other key presses until it p is pressed and pauses the broker and workers
-func handlePause(client *rpc.Client, keyPresses <-chan rune) {
+// handlePause blocks other key presses until it p is pressed and pauses the broker and workers.
+// While paused the window shows the world, and edits are applied to it
+func handlePause(client stubs.Caller, keyPresses <-chan rune, edits <-chan Edit, views <-chan Viewport, c distributorChannels, shown []util.BitArray, view *Viewport) {
 	pause := true
 	var empty struct{}
 	turnResponse := new(stubs.PauseServerResponse)
@@ -91,8 +99,17 @@ func handlePause(client *rpc.Client, keyPresses <-chan rune) {
 		fmt.Println(err)
 	}
 	fmt.Println("#PAUSED\nCompleted Turns", turnResponse.CompletedTurns)
+	c.events <- StateChange{turnResponse.CompletedTurns, Paused}
+	if views != nil {
+		showWorld(client, c, shown, *view)
+	}
 	for pause {
 		select {
+		case edit := <-edits:
+			applyEdits(client, edit, edits, true)
+			showWorld(client, c, shown, *view)
+		case *view = <-views:
+			showWorld(client, c, shown, *view)
 		case k := <-keyPresses:
 			if k == 'p' {
 				if err := client.Call(stubs.PauseServer, empty, &empty); err != nil {
@@ -100,6 +117,7 @@ func handlePause(client *rpc.Client, keyPresses <-chan rune) {
 				} else {
 					pause = false
 					fmt.Println("#CONTINUING")
+					c.events <- StateChange{turnResponse.CompletedTurns, Executing}
 				}
 			}
 		}
@@ -107,15 +125,19 @@ func handlePause(client *rpc.Client, keyPresses <-chan rune) {
 }
 
 // handleKeyPresses takes a keypress and acts accordingly, it returns a boolean value indicting whether the program should halt
-func handleKeyPresses(key rune, keyPresses <-chan rune, p Params, c distributorChannels, client *rpc.Client, filename string) bool {
+func handleKeyPresses(key rune, keyPresses <-chan rune, edits <-chan Edit, views <-chan Viewport, p Params, c distributorChannels, client stubs.Caller, filename string, shown []util.BitArray, view *Viewport) bool {
 	switch key {
 	case 's': // save: outputs current world
 		worldResponse := getCurrentWorld(client)
-		outputWorld(p.ImageHeight, p.ImageWidth, worldResponse.CompletedTurns, worldResponse.World, filename, c)
+		if worldResponse.States != nil {
+			outputStates(p, worldResponse.CompletedTurns, worldResponse.States, filename, c)
+		} else {
+			outputWorld(p, worldResponse.CompletedTurns, worldResponse.World, filename, c)
+		}
 	case 'q': // quit: ends the client program
 		worldResponse := getCurrentWorld(client)
 		haltTurns(client)
-		exit(p, c, worldResponse.CompletedTurns, worldResponse.World, filename)
+		exit(p, c, worldResponse.CompletedTurns, worldResponse.World, worldResponse.States, filename)
 		return true
 	case 'k': //kill: shuts down the workers, then broker, then client
 		haltClientResponse := new(struct{})
@@ -124,93 +146,195 @@ func handleKeyPresses(key rune, keyPresses <-chan rune, p Params, c distributorC
 		}
 		haltTurns(client)
 	case 'p': //pause
-		handlePause(client, keyPresses)
+		handlePause(client, keyPresses, edits, views, c, shown, view)
 	}
 	return false
 }
 
-// exit saves the world in its current state and ensures that the program stops gracefully
-func exit(p Params, c distributorChannels, turnsCompleted int, world []util.BitArray, filename string) {
+// exit saves the world in its current state and ensures that the program stops gracefully, states is nil for Life
+func exit(p Params, c distributorChannels, turnsCompleted int, world []util.BitArray, states []util.StateArray, filename string) {
 	// Report the final state using FinalTurnCompleteEvent.
-	c.events <- FinalTurnComplete{CompletedTurns: turnsCompleted, Alive: finalAliveCount(world)}
-	outputWorld(p.ImageHeight, p.ImageWidth, turnsCompleted, world, filename, c)
+	if states != nil {
+		c.events <- FinalTurnComplete{CompletedTurns: turnsCompleted, Alive: finalAliveStates(states)}
+		outputStates(p, turnsCompleted, states, filename, c)
+	} else {
+		c.events <- FinalTurnComplete{CompletedTurns: turnsCompleted, Alive: finalAliveCount(world)}
+		outputWorld(p, turnsCompleted, world, filename, c)
+	}
+
+	if p.Record.Every > 0 {
+		c.ioCommand <- ioSaveRecording
+		c.ioFilename <- p.outputName(fmt.Sprintf("%d-%d", p.Record.Start, p.Record.End))
+	}
 
 	// Make sure that the Io has finished any output before exiting.
 	c.ioCommand <- ioCheckIdle
-	<-c.ioIdle
+	if err := <-c.ioIdle; err != nil {
+		fail(c, turnsCompleted, err)
+		return
+	}
 	c.events <- StateChange{turnsCompleted, Quitting}
 	// Close the channel to stop the SDL goroutine gracefully. Removing may cause deadlock.
 	close(c.events)
 }
 
+// fail reports that the run could not carry on, or that its images could not be written, and stops the program
+// without writing anything more
+func fail(c distributorChannels, turnsCompleted int, err error) {
+	fmt.Println("#FAILED:", err)
+	c.events <- Failed{CompletedTurns: turnsCompleted, Err: err}
+	c.events <- StateChange{turnsCompleted, Quitting}
+	close(c.events)
+}
+
+// loadWorld reads the initial world in through the io goroutine
+func loadWorld(p Params, c distributorChannels) ([]util.BitArray, error) {
+	// Loads world from input
+	c.ioCommand <- ioInput        // Triggers ReadPgmImage()
+	c.ioFilename <- p.inputPath() // ReadPgmImage waits for this filename
+	input := <-c.ioInput
+	return input.world, input.err
+}
+
 // runGameOfLife starts running the GoL through the broker
-func runGameOfLife(client *rpc.Client, p Params, c distributorChannels, keyPresses <-chan rune) {
+func runGameOfLife(client stubs.Caller, p Params, c distributorChannels, keyPresses <-chan rune, edits <-chan Edit, views <-chan Viewport) {
 	timer := time.NewTimer(2 * time.Second)
 	done := make(chan error)
 	resume := p.Turns >= 1000000 //10000000000 - if it is `run .` this is the case. perhaps there is a more exact way of doing this
 
 	filename := strconv.Itoa(p.ImageWidth) + "x" + strconv.Itoa(p.ImageHeight)
-
-	// Create the world and nextWorld as 2D slices
-	world := makeWorld(p.ImageWidth, p.ImageHeight)
-
-	// Loads world from input
-	c.ioCommand <- ioInput               // Triggers ReadPgmImage()
-	c.ioFilename <- filename             // ReadPgmImage waits for this filename
-	for i := 0; i < p.ImageHeight; i++ { // each row
-		for j := 0; j < p.ImageWidth; j++ { // each value in row
-			world[i].SetBitFromUint8(j, <-c.ioInput) //byte by byte pixels of image
+	hello, err := greetBroker(client, p)
+	if err != nil {
+		fmt.Println("#REFUSED BROKER:", err)
+		fail(c, 0, fmt.Errorf("the broker refused the run: %v", err))
+		return
+	}
+	if hello.Version < stubs.ProtocolVersion {
+		fmt.Println("#OLD BROKER VERSION", hello.Version)
+	}
+	if p.Engine == stubs.EngineHashLife && !hello.Has(stubs.FeatureHashLife) {
+		fmt.Println("#USING STEP ENGINE: the broker does not support hashlife")
+		p.Engine = stubs.EngineStep
+	}
+	if p.Threads > 0 && !hello.Has(stubs.FeatureThreads) {
+		fmt.Println("#IGNORING THREADS: the workers choose their own")
+	}
+	if !hello.Has(stubs.FeatureEdits) {
+		edits = ignoreEdits(edits)
+	}
+	if !hello.Has(stubs.FeatureRegions) {
+		views = ignoreViews(views)
+	}
+	var world []util.BitArray
+	var states []util.StateArray
+	if rule.IsLife(p.Rule) {
+		world, err = loadWorld(p, c)
+	} else {
+		var r rule.Rule
+		if r, err = rule.Parse(p.Rule); err == nil {
+			states, err = loadStates(p, c, r)
 		}
 	}
+	if err != nil {
+		fail(c, 0, err)
+		return
+	}
 	turns := p.Turns
 	width := p.ImageWidth
 	height := p.ImageHeight
 
-	request := stubs.Request{Turns: turns, ImageWidth: width, ImageHeight: height, World: world, Resume: resume}
+	// when recording, the broker is stopped on each recorded turn and resumed once the frame has been taken
+	completed := 0
+	if resume && p.Record.Every > 0 {
+		aliveResponse := new(stubs.AliveCellsResponse)
+		if err := client.Call(stubs.GetAliveCount, struct{}{}, aliveResponse); err == nil {
+			completed = aliveResponse.CompletedTurns
+		}
+	} else if p.Record.On(0) {
+		recordFrame(p, c, world, states)
+	}
+
+	request := stubs.Request{Turns: p.Record.Next(completed, turns), ImageWidth: width, ImageHeight: height, World: world, Resume: resume, Engine: p.Engine, Boundary: p.Boundary, Rule: p.Rule, States: states, Threads: p.Threads}
 	response := new(stubs.Response)
-	go func() {
+	run := func() {
 		err := client.Call(stubs.RunGameOfLife, request, response)
 		done <- err
-	}()
+	}
+	go run()
 
+	if hello.Has(stubs.FeatureStats) {
+		workersConnected(client, c)
+	}
+	shown := makeWorld(height, width) // the cells the window shows as alive
+	view := wholeWorld(p)
 	halt := false
 	for !halt {
 		select {
 		case err := <-done:
 			if err != nil {
-				fmt.Println(err)
+				// the broker sends no world with an error, so there is nothing to write out
+				fail(c, response.CompletedTurns, err)
+				return
+			}
+			if p.Record.On(response.CompletedTurns) {
+				recordFrame(p, c, response.NextWorld, response.NextStates)
 			}
-			exit(p, c, response.CompletedTurns, response.NextWorld, filename)
+			if response.CompletedTurns == request.Turns && request.Turns < turns {
+				request.Turns = p.Record.Next(request.Turns, turns)
+				request.Resume = true
+				request.World, request.States = nil, nil
+				response = new(stubs.Response)
+				go run()
+				continue
+			}
+			exit(p, c, response.CompletedTurns, response.NextWorld, response.NextStates, filename)
 			halt = true
 		case k := <-keyPresses:
-			halt = handleKeyPresses(k, keyPresses, p, c, client, filename)
+			halt = handleKeyPresses(k, keyPresses, edits, views, p, c, client, filename, shown, &view)
+		case edit := <-edits:
+			// cells are only drawn while paused, but patterns can be dropped into the running world
+			if applyEdits(client, edit, edits, false) {
+				showWorld(client, c, shown, view)
+			}
+		case view = <-views:
+			showWorld(client, c, shown, view)
 		case <-timer.C:
 			regularAliveCount(client, c)
+			if views != nil {
+				showWorld(client, c, shown, view)
+			}
 			timer.Reset(2 * time.Second)
 		}
 	}
 }
 
 // distributor divides the work between workers and interacts with other goroutines.
-func distributor(p Params, c distributorChannels, keyPresses <-chan rune) {
+func distributor(p Params, c distributorChannels, keyPresses <-chan rune, edits <-chan Edit, views <-chan Viewport) {
+	if p.Local {
+		runLocalHashLife(p, c, keyPresses, edits, views)
+		return
+	}
 	var serverAddress string
-	if len(os.Args) == 2 {
-		serverAddress = os.Args[1]
+	if flag.Parsed() && flag.NArg() == 1 {
+		serverAddress = flag.Arg(0)
 		fmt.Println("#USING ARGUMENT ADDRESS")
+	} else if p.GRPC {
+		serverAddress = "127.0.0.1:8040"
+		fmt.Println("#USING DEFAULT GRPC ADDRESS")
 	} else {
 		serverAddress = "127.0.0.1:8030"
 		fmt.Println("#USING DEFAULT ADDRESS")
 	}
-	client, err := rpc.Dial("tcp", serverAddress)
+	client, err := pb.DialCaller(serverAddress, p.GRPC)
 	if err != nil {
-		fmt.Println(err)
+		fail(c, 0, err)
 		return
 	}
-	defer func(client *rpc.Client) {
+	defer func(client stubs.Caller) {
 		if err := client.Close(); err != nil {
 			fmt.Println(err)
 			return
 		}
 	}(client)
-	runGameOfLife(client, p, c, keyPresses)
+	runGameOfLife(client, p, c, keyPresses, edits, views)
 }
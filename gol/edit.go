@@ -0,0 +1,52 @@
+package gol
+
+import (
+	"fmt"
+	"uk.ac.bris.cs/gameoflife/stubs"
+	"uk.ac.bris.cs/gameoflife/util"
+)
+
+// Edit is a change to the world made by the user, sent to the distributor alongside key presses.
+// When Pattern is set it is a pattern in RLE that is stamped with its top left at Cell, otherwise Cell is made
+// alive or dead
+type Edit struct {
+	Cell    util.Cell
+	Alive   bool
+	Pattern string
+}
+
+// applyEdit makes an RPC call to change the world in the broker between two turns
+func applyEdit(client stubs.Caller, edit Edit) {
+	response := new(stubs.AliveCellsResponse)
+	var err error
+	if edit.Pattern != "" {
+		err = client.Call(stubs.StampPattern, stubs.StampRequest{X: edit.Cell.X, Y: edit.Cell.Y, RLE: edit.Pattern}, response)
+	} else {
+		region := stubs.RegionRequest{X: edit.Cell.X, Y: edit.Cell.Y, Width: 1, Height: 1}
+		if edit.Alive {
+			region.State = 1
+		}
+		err = client.Call(stubs.SetRegion, region, response)
+	}
+	if err != nil {
+		fmt.Println(err)
+	}
+}
+
+// applyEdits applies edit and then every edit already waiting in edits, so that the world only has to be shown once
+// for a whole stroke of the mouse. Unless paused only patterns are applied, as cells are only drawn while paused. It
+// returns whether any edit was applied
+func applyEdits(client stubs.Caller, edit Edit, edits <-chan Edit, paused bool) bool {
+	applied := false
+	for {
+		if paused || edit.Pattern != "" {
+			applyEdit(client, edit)
+			applied = true
+		}
+		select {
+		case edit = <-edits:
+		default:
+			return applied
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package gol
+
+import (
+	"testing"
+	"uk.ac.bris.cs/gameoflife/stubs"
+	"uk.ac.bris.cs/gameoflife/util"
+)
+
+// recordingCaller stands in for the broker, recording the calls made to it
+type recordingCaller struct {
+	calls []string
+}
+
+func (r *recordingCaller) Call(serviceMethod string, _ interface{}, _ interface{}) error {
+	r.calls = append(r.calls, serviceMethod)
+	return nil
+}
+
+func (r *recordingCaller) Close() error {
+	return nil
+}
+
+// TestApplyEdits checks that the edits waiting behind the first are applied with it, and that only patterns are
+// applied to a running world
+func TestApplyEdits(t *testing.T) {
+	edits := make(chan Edit, 10)
+	for x := 1; x < 5; x++ {
+		edits <- Edit{Cell: util.Cell{X: x}, Alive: true}
+	}
+	client := new(recordingCaller)
+	if !applyEdits(client, Edit{Alive: true}, edits, true) || len(client.calls) != 5 || len(edits) != 0 {
+		t.Errorf("a stroke of 5 cells while paused made the calls %v and left %d edits, want 5 calls and none left", client.calls, len(edits))
+	}
+
+	client = new(recordingCaller)
+	edits <- Edit{Pattern: "o!"}
+	edits <- Edit{Cell: util.Cell{X: 2}, Alive: true}
+	if !applyEdits(client, Edit{Alive: true}, edits, false) || len(client.calls) != 1 || client.calls[0] != stubs.StampPattern {
+		t.Errorf("a cell, a pattern and a cell while running made the calls %v, want only %s", client.calls, stubs.StampPattern)
+	}
+	client = new(recordingCaller)
+	if applyEdits(client, Edit{Alive: true}, edits, false) || len(client.calls) != 0 {
+		t.Errorf("a cell while running made the calls %v, want none", client.calls)
+	}
+}
@@ -67,6 +67,21 @@ type FinalTurnComplete struct {
 	Alive          []util.Cell
 }
 
+// WorkersConnected is an Event notifying the GUI about how many workers are computing the turns.
+// This Event is sent once the distributor has connected, with 0 workers when running locally.
+type WorkersConnected struct { // implements Event
+	CompletedTurns int
+	Workers        int
+}
+
+// Failed is an Event notifying the user that the run could not carry on or that an image could not be written.
+// It is sent instead of FinalTurnComplete when the world cannot be loaded, and after it when the final images
+// cannot be written. The events channel is closed straight after it.
+type Failed struct { // implements Event
+	CompletedTurns int
+	Err            error
+}
+
 // String methods allow the different types of Events and States to be printed.
 
 func (state State) String() string {
@@ -122,6 +137,14 @@ func (event TurnComplete) GetCompletedTurns() int {
 	return event.CompletedTurns
 }
 
+func (event WorkersConnected) String() string {
+	return fmt.Sprintf("%v workers connected", event.Workers)
+}
+
+func (event WorkersConnected) GetCompletedTurns() int {
+	return event.CompletedTurns
+}
+
 func (event FinalTurnComplete) String() string {
 	return fmt.Sprintf("")
 }
@@ -130,6 +153,14 @@ func (event FinalTurnComplete) GetCompletedTurns() int {
 	return event.CompletedTurns
 }
 
+func (event Failed) String() string {
+	return fmt.Sprintf("Failed: %v", event.Err)
+}
+
+func (event Failed) GetCompletedTurns() int {
+	return event.CompletedTurns
+}
+
 // This might all seem like weird syntax to you...
 // You have however seen something similar to it before in first year.
 
@@ -1,21 +1,54 @@
 package gol
 
+import (
+	"image/color"
+	"time"
+	"uk.ac.bris.cs/gameoflife/util"
+)
+
 // Params provides the details of how to run the Game of Life and which image to load.
 type Params struct {
 	Turns       int
 	Threads     int
 	ImageWidth  int
 	ImageHeight int
+	Engine      string // stubs.EngineStep (the default) or stubs.EngineHashLife
+	Local       bool   // run HashLife in this process instead of connecting to a broker
+	GRPC        bool   // call the broker over gRPC instead of net/rpc
+	Boundary    util.Boundary
+	Rule        string     // rule string such as B3/S23 or B2/S345/C4, empty for Life
+	Format      string     // FormatPGM (the default) or FormatPNG for the snapshots the io goroutine writes
+	Scale       int        // width in pixels of each cell in PNG and GIF images, 1 if not set
+	Alive, Dead color.RGBA // colours of alive and dead cells in PNG and GIF images, white and black if not set
+	Record      Recording  // the turns to record into an animated GIF
+	Input       string     // pgm image the world is loaded from, images/<width>x<height>.pgm if not set
+	Threshold   uint8      // grey level a Life cell of the input image must be above to be alive, so 0 is any but black
+	OutDir      string     // directory that images are written to, out if not set
+	Template    string     // name of each image written, with placeholders as in DefaultTemplate, which is used if not set
+	Started     time.Time  // the time used for the {time} placeholder, set by Run if not set
+	Soup        Soup       // a random world to start from instead of the input image, when its Density is set
+	Pattern     string     // RLE file of a pattern that can be dropped into the world from the window
+	Colouring   string     // how the window colours cells: plain (the default), age or change
+	Palette     string     // the palette the window colours cells by age in
+
+	// the window sends what the user does to the run through these. Both are nil when nothing shows the world, so
+	// that it is not fetched from the broker, and the terminal only needs Views to be set
+	Edits <-chan Edit     // the changes the user makes to the world
+	Views <-chan Viewport // the part of the world the window shows
 }
 
 // Run starts the processing of Game of Life. It should initialise channels and goroutines.
 func Run(p Params, events chan<- Event, keyPresses <-chan rune) {
+	if p.Started.IsZero() {
+		p.Started = time.Now()
+	}
+	p.Soup.chooseSeed(p.Started)
 
 	ioCommand := make(chan ioCommand)
-	ioIdle := make(chan bool)
+	ioIdle := make(chan error)
 	ioFilename := make(chan string)
-	ioInput := make(chan uint8)
-	ioOutput := make(chan uint8)
+	ioInput := make(chan ioImage)
+	ioOutput := make(chan ioImage)
 
 	ioChannels := ioChannels{
 		command:  ioCommand,
@@ -34,5 +67,5 @@ func Run(p Params, events chan<- Event, keyPresses <-chan rune) {
 		ioOutput:   ioOutput,
 		ioInput:    ioInput,
 	}
-	distributor(p, distributorChannels, keyPresses)
+	distributor(p, distributorChannels, keyPresses, p.Edits, p.Views)
 }
@@ -0,0 +1,69 @@
+package gol
+
+import (
+	"fmt"
+	"uk.ac.bris.cs/gameoflife/rule"
+	"uk.ac.bris.cs/gameoflife/stubs"
+	"uk.ac.bris.cs/gameoflife/util"
+)
+
+// controllerHello is what the controller can ask of the broker
+func controllerHello() stubs.Hello {
+	return stubs.Hello{
+		Version:    stubs.ProtocolVersion,
+		MinVersion: stubs.MinProtocolVersion,
+		Rules:      rule.Families,
+		Encodings:  []string{stubs.EncodingBits, stubs.EncodingStates},
+		Features: []string{stubs.FeatureHashLife, stubs.FeatureEdits, stubs.FeatureRegions, stubs.FeatureStats,
+			stubs.FeatureBoundaries, stubs.FeatureThreads},
+	}
+}
+
+// greetBroker makes the Hello call to the broker and returns what both support, or an error if the broker cannot
+// be talked to or cannot run the rule and boundary of p. Anything else that is missing is left to the caller to do
+// without
+func greetBroker(client stubs.Caller, p Params) (stubs.Hello, error) {
+	hello, err := stubs.CallHello(client, stubs.BrokerHello, controllerHello())
+	if err != nil {
+		return hello, err
+	}
+	r, err := rule.Parse(p.Rule)
+	if err != nil {
+		return hello, err
+	}
+	family := rule.Family(r)
+	if !hello.HasRule(family) {
+		return hello, fmt.Errorf("the broker cannot run %s rules such as %v", family, r)
+	}
+	if family != "life" && !hello.HasEncoding(stubs.EncodingStates) {
+		return hello, fmt.Errorf("the broker can only send worlds of Life")
+	}
+	if p.Boundary != util.Torus && !hello.Has(stubs.FeatureBoundaries) {
+		return hello, fmt.Errorf("the broker only supports the torus boundary")
+	}
+	return hello, nil
+}
+
+// ignoreEdits throws away the edits made in the window when the broker cannot apply them, returning nil so that
+// the distributor never receives any
+func ignoreEdits(edits <-chan Edit) <-chan Edit {
+	if edits != nil {
+		go func() {
+			for range edits {
+			}
+		}()
+	}
+	return nil
+}
+
+// ignoreViews throws away the viewports of the window when the broker cannot send regions of the world, returning
+// nil so that the distributor never receives any
+func ignoreViews(views <-chan Viewport) <-chan Viewport {
+	if views != nil {
+		go func() {
+			for range views {
+			}
+		}()
+	}
+	return nil
+}
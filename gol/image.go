@@ -0,0 +1,202 @@
+package gol
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"strconv"
+	"strings"
+	"uk.ac.bris.cs/gameoflife/rule"
+	"uk.ac.bris.cs/gameoflife/util"
+)
+
+// Formats the io goroutine can write snapshots in
+const (
+	FormatPGM = "pgm"
+	FormatPNG = "png"
+)
+
+// gifDelay is the time each frame of a recording is shown for, in hundredths of a second
+const gifDelay = 10
+
+// Recording is the turns recorded into an animated GIF, every Every turns from Start to End inclusive
+type Recording struct {
+	Start, End, Every int
+}
+
+// ParseRecording parses a range of turns written as start:end:every, or start:end to record every turn.
+// An empty string records nothing
+func ParseRecording(s string) (Recording, error) {
+	if s == "" {
+		return Recording{}, nil
+	}
+	parts := strings.Split(s, ":")
+	if len(parts) == 2 {
+		parts = append(parts, "1")
+	}
+	if len(parts) != 3 {
+		return Recording{}, fmt.Errorf("recording %q should be start:end:every", s)
+	}
+	var numbers [3]int
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return Recording{}, fmt.Errorf("recording %q should be three turn numbers such as 0:100:5", s)
+		}
+		numbers[i] = n
+	}
+	r := Recording{Start: numbers[0], End: numbers[1], Every: numbers[2]}
+	if r.Every == 0 || r.End < r.Start {
+		return Recording{}, fmt.Errorf("recording %q should end after it starts and record every 1 or more turns", s)
+	}
+	return r, nil
+}
+
+// On reports whether a turn is recorded
+func (r Recording) On(turn int) bool {
+	return r.Every > 0 && turn >= r.Start && turn <= r.End && (turn-r.Start)%r.Every == 0
+}
+
+// Next returns the first recorded turn after turn, stopping at end if there is none before it
+func (r Recording) Next(turn, end int) int {
+	if r.Every == 0 {
+		return end
+	}
+	next := r.Start
+	if turn >= r.Start {
+		next = r.Start + ((turn-r.Start)/r.Every+1)*r.Every
+	}
+	if next > r.End || next > end {
+		return end
+	}
+	return next
+}
+
+// ParseColour parses a colour written in hex as #rrggbb or rrggbb
+func ParseColour(s string) (color.RGBA, error) {
+	n, err := strconv.ParseUint(strings.TrimPrefix(s, "#"), 16, 32)
+	if err != nil || len(strings.TrimPrefix(s, "#")) != 6 {
+		return color.RGBA{}, fmt.Errorf("colour %q should be in hex such as #ffffff", s)
+	}
+	return color.RGBA{R: uint8(n >> 16), G: uint8(n >> 8), B: uint8(n), A: 255}, nil
+}
+
+// recordFrame sends the world to the io goroutine to be added to the recording
+func recordFrame(p Params, c distributorChannels, world []util.BitArray, states []util.StateArray) {
+	c.ioCommand <- ioRecord
+	if states != nil {
+		c.ioOutput <- stateGreys(p, states)
+		return
+	}
+	c.ioOutput <- ioImage{world: world}
+}
+
+// palette returns the colour of each byte of an image from the distributor. For Life any byte but 0 is alive,
+// for other rules each state is drawn in the colour of its rule, fading from the alive to the dead colour for rules
+// without their own colours
+func (io *ioState) palette() color.Palette {
+	alive, dead := io.params.Alive, io.params.Dead
+	if alive == (color.RGBA{}) {
+		alive = color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	}
+	dead.A = 255
+
+	palette := make(color.Palette, 256)
+	for grey := range palette {
+		palette[grey] = color.RGBA{
+			R: uint8((int(dead.R)*(255-grey) + int(alive.R)*grey) / 255),
+			G: uint8((int(dead.G)*(255-grey) + int(alive.G)*grey) / 255),
+			B: uint8((int(dead.B)*(255-grey) + int(alive.B)*grey) / 255),
+			A: 255,
+		}
+	}
+	r, err := rule.Parse(io.params.Rule)
+	if err != nil || rule.IsLife(io.params.Rule) {
+		for grey := 1; grey < len(palette); grey++ {
+			palette[grey] = alive
+		}
+		return palette
+	}
+	if _, ok := r.(rule.Coloured); ok {
+		for state := 0; state < r.States(); state++ {
+			palette[rule.Grey(r, uint8(state))] = rule.Colour(r, uint8(state))
+		}
+	}
+	return palette
+}
+
+// receiveImage receives a whole image from the distributor, scaling every cell up to a square of pixels
+func (io *ioState) receiveImage(palette color.Palette) *image.Paletted {
+	scale := io.params.Scale
+	if scale < 1 {
+		scale = 1
+	}
+	cells := <-io.channels.output
+	img := image.NewPaletted(image.Rect(0, 0, io.params.ImageWidth*scale, io.params.ImageHeight*scale), palette)
+	for y := 0; y < io.params.ImageHeight; y++ {
+		for x := 0; x < io.params.ImageWidth; x++ {
+			index := cells.at(x, y)
+			for dy := 0; dy < scale; dy++ {
+				row := img.Pix[(y*scale+dy)*img.Stride:]
+				for dx := 0; dx < scale; dx++ {
+					row[x*scale+dx] = index
+				}
+			}
+		}
+	}
+	return img
+}
+
+// writePngImage receives an image from the distributor and writes it to a png file
+func (io *ioState) writePngImage() {
+	// Request a filename from the distributor.
+	filename := <-io.channels.filename
+	img := io.receiveImage(io.palette())
+
+	file, err := io.params.createOutput(filename, "png")
+	if err != nil {
+		io.fail(filename, err)
+		return
+	}
+	defer file.Close()
+	if err := png.Encode(file, img); err != nil {
+		io.fail(filename, err)
+		return
+	}
+
+	fmt.Println("File", filename, "output done!")
+}
+
+// recordFrame receives an image from the distributor and keeps it as the next frame of the recording
+func (io *ioState) recordFrame() {
+	io.frames = append(io.frames, io.receiveImage(io.palette()))
+}
+
+// saveRecording writes the frames recorded so far to an animated gif file, and starts a new recording
+func (io *ioState) saveRecording() {
+	// Request a filename from the distributor.
+	filename := <-io.channels.filename
+	if len(io.frames) == 0 {
+		return
+	}
+	animation := &gif.GIF{Image: io.frames, Delay: make([]int, len(io.frames))}
+	for i := range animation.Delay {
+		animation.Delay[i] = gifDelay
+	}
+	io.frames = nil
+
+	file, err := io.params.createOutput(filename, "gif")
+	if err != nil {
+		io.fail(filename, err)
+		return
+	}
+	defer file.Close()
+	if err := gif.EncodeAll(file, animation); err != nil {
+		io.fail(filename, err)
+		return
+	}
+
+	fmt.Println("File", filename, "output done!")
+}
@@ -0,0 +1,49 @@
+package gol
+
+import "testing"
+
+// TestRecording checks parsing a recording and stepping through the turns it records
+func TestRecording(t *testing.T) {
+	r, err := ParseRecording("10:40:15")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var stops []int
+	for turn := 0; turn < 100; turn = r.Next(turn, 100) {
+		stops = append(stops, turn)
+	}
+	want := []int{0, 10, 25, 40}
+	if len(stops) != len(want) {
+		t.Fatalf("stopped on %v, want %v", stops, want)
+	}
+	for i := range want {
+		if stops[i] != want[i] || (i > 0) != r.On(stops[i]) {
+			t.Fatalf("stopped on %v, want %v with every stop but 0 recorded", stops, want)
+		}
+	}
+	if r.Next(0, 5) != 5 {
+		t.Errorf("a run of 5 turns should stop at 5, not %d", r.Next(0, 5))
+	}
+
+	for _, bad := range []string{"1", "5:1:1", "0:10:0", "a:b:c", "0:10:5:1"} {
+		if _, err := ParseRecording(bad); err == nil {
+			t.Errorf("ParseRecording(%q) should fail", bad)
+		}
+	}
+	if r, _ := ParseRecording("0:10"); r.Every != 1 {
+		t.Errorf("0:10 should record every turn")
+	}
+}
+
+// TestParseColour checks hex colours with and without a #
+func TestParseColour(t *testing.T) {
+	c, err := ParseColour("#ff8001")
+	if err != nil || c.R != 0xff || c.G != 0x80 || c.B != 0x01 || c.A != 0xff {
+		t.Errorf("ParseColour(#ff8001) = %v, %v", c, err)
+	}
+	for _, bad := range []string{"fff", "#gg0000", "#ff00001"} {
+		if _, err := ParseColour(bad); err == nil {
+			t.Errorf("ParseColour(%q) should fail", bad)
+		}
+	}
+}
@@ -1,27 +1,54 @@
 package gol
 
 import (
+	"bufio"
 	"fmt"
-	"io/ioutil"
-	"os"
-	"strconv"
-	"strings"
+	"image"
+	"uk.ac.bris.cs/gameoflife/pnm"
+	"uk.ac.bris.cs/gameoflife/rule"
 	"uk.ac.bris.cs/gameoflife/util"
 )
 
 type ioChannels struct {
 	command <-chan ioCommand
-	idle    chan<- bool
+	idle    chan<- error
 
 	filename <-chan string
-	output   <-chan uint8
-	input    chan<- uint8
+	output   <-chan ioImage
+	input    chan<- ioImage
+}
+
+// ioImage is a whole image passed between the distributor and the io goroutine. A Life world is packed one bit
+// per cell in world, for any other rule greys holds the grey level of each cell. err is set instead when the input
+// image cannot be read
+type ioImage struct {
+	world []util.BitArray
+	greys [][]uint8
+	err   error
+}
+
+// at returns the byte for the cell at x, y, which is 1 for an alive cell of a Life world
+func (img ioImage) at(x, y int) uint8 {
+	if img.greys != nil {
+		return img.greys[y][x]
+	}
+	return img.world[y].GetBitToUint8(x)
 }
 
 // ioState is the internal ioState of the io goroutine.
 type ioState struct {
 	params   Params
 	channels ioChannels
+	frames   []*image.Paletted // the frames recorded so far for an animated gif
+	err      error             // the first output error since the distributor last checked that the io was idle
+}
+
+// fail reports an output error, keeping it for the distributor to find when it next checks that the io is idle
+func (io *ioState) fail(filename string, err error) {
+	fmt.Println("#IO ERROR:", err)
+	if io.err == nil {
+		io.err = fmt.Errorf("writing %s: %v", filename, err)
+	}
 }
 
 // ioCommand allows requesting behaviour from the io (pgm) goroutine.
@@ -32,95 +59,105 @@ type ioCommand uint8
 //		ioOutput 	= 0
 //		ioInput 	= 1
 //		ioCheckIdle = 2
+//		ioRecord = 3
+//		ioSaveRecording = 4
 const (
 	ioOutput ioCommand = iota
 	ioInput
 	ioCheckIdle
+	ioRecord
+	ioSaveRecording
 )
 
-// writePgmImage receives an array of bytes and writes it to a pgm file.
+// writePgmImage receives a whole image from the distributor and writes it to a pgm file.
 func (io *ioState) writePgmImage() {
-	_ = os.Mkdir("out", os.ModePerm)
-
 	// Request a filename from the distributor.
 	filename := <-io.channels.filename
+	img := <-io.channels.output
 
-	file, ioError := os.Create("out/" + filename + ".pgm")
-	util.Check(ioError)
+	file, err := io.params.createOutput(filename, "pgm")
+	if err != nil {
+		io.fail(filename, err)
+		return
+	}
 	defer file.Close()
 
-	_, _ = file.WriteString("P5\n")
-	//_, _ = file.WriteString("# PGM file writer by pnmmodules (https://github.com/owainkenwayucl/pnmmodules).\n")
-	_, _ = file.WriteString(strconv.Itoa(io.params.ImageWidth))
-	_, _ = file.WriteString(" ")
-	_, _ = file.WriteString(strconv.Itoa(io.params.ImageHeight))
-	_, _ = file.WriteString("\n")
-	_, _ = file.WriteString(strconv.Itoa(255))
-	_, _ = file.WriteString("\n")
-
-	world := make([][]byte, io.params.ImageHeight)
-	for i := range world {
-		world[i] = make([]byte, io.params.ImageWidth)
+	writer := bufio.NewWriter(file)
+	_, _ = fmt.Fprint(writer, "P5\n")
+	if io.params.Soup.Density > 0 {
+		_, _ = fmt.Fprintf(writer, "# soup %v\n", io.params.Soup)
 	}
+	_, _ = fmt.Fprintf(writer, "%d %d\n%d\n", io.params.ImageWidth, io.params.ImageHeight, 255)
 
+	row := make([]byte, io.params.ImageWidth)
 	for y := 0; y < io.params.ImageHeight; y++ {
-		for x := 0; x < io.params.ImageWidth; x++ {
-			val := <-io.channels.output
-			//if val != 0 {
-			//	fmt.Println(x, y)
-			//}
-			world[y][x] = val
+		for x := range row {
+			row[x] = img.at(x, y)
 		}
-	}
-
-	for y := 0; y < io.params.ImageHeight; y++ {
-		for x := 0; x < io.params.ImageWidth; x++ {
-			_, ioError = file.Write([]byte{world[y][x]})
-			util.Check(ioError)
+		if _, err := writer.Write(row); err != nil {
+			io.fail(filename, err)
+			return
 		}
 	}
-
-	ioError = file.Sync()
-	util.Check(ioError)
+	if err := writer.Flush(); err != nil {
+		io.fail(filename, err)
+		return
+	}
+	if err := file.Sync(); err != nil {
+		io.fail(filename, err)
+		return
+	}
 
 	fmt.Println("File", filename, "output done!")
 }
 
-// readPgmImage opens a pgm file and sends its data as an array of bytes.
+// readPgmImage opens a pgm file, or generates the soup if there is one, and sends it to the distributor in one go,
+// packed into bits for Life and as the grey level of each pixel for any other rule. A Life cell is alive when its
+// grey level is above the threshold in the params. If the file is missing, corrupt or the wrong size the error is
+// sent instead.
 func (io *ioState) readPgmImage() {
 
 	// Request a filename from the distributor.
 	filename := <-io.channels.filename
 
-	data, ioError := ioutil.ReadFile("images/" + filename + ".pgm")
-	util.Check(ioError)
-
-	fields := strings.Fields(string(data))
-
-	if fields[0] != "P5" {
-		panic("Not a pgm file")
-	}
-
-	width, _ := strconv.Atoi(fields[1])
-	if width != io.params.ImageWidth {
-		panic("Incorrect width")
-	}
-
-	height, _ := strconv.Atoi(fields[2])
-	if height != io.params.ImageHeight {
-		panic("Incorrect height")
-	}
-
-	maxval, _ := strconv.Atoi(fields[3])
-	if maxval != 255 {
-		panic("Incorrect maxval/bit depth")
+	var img *pnm.Image
+	if io.params.Soup.Density > 0 {
+		alive := uint8(255)
+		if !rule.IsLife(io.params.Rule) {
+			alive = rule.Grey(paramsRule(io.params), 1)
+		}
+		img = io.params.Soup.Generate(io.params.ImageWidth, io.params.ImageHeight, alive)
+		filename = "soup " + io.params.Soup.String()
+		fmt.Println("#SOUP", io.params.Soup)
+	} else {
+		var err error
+		img, err = pnm.ReadFile(filename)
+		if err == nil && (img.Width != io.params.ImageWidth || img.Height != io.params.ImageHeight) {
+			err = fmt.Errorf("%s is %dx%d, not %dx%d", filename, img.Width, img.Height, io.params.ImageWidth, io.params.ImageHeight)
+		}
+		if err != nil {
+			fmt.Println("#IO ERROR:", err)
+			io.channels.input <- ioImage{err: err}
+			return
+		}
 	}
 
-	image := []byte(fields[4])
-
-	for _, b := range image {
-		io.channels.input <- b
+	var input ioImage
+	if rule.IsLife(io.params.Rule) {
+		input.world = make([]util.BitArray, img.Height)
+		for y := range input.world {
+			input.world[y] = util.NewBitArray(img.Width)
+			for x := 0; x < img.Width; x++ {
+				input.world[y].SetBit(x, img.At(x, y) > io.params.Threshold)
+			}
+		}
+	} else {
+		input.greys = make([][]uint8, img.Height)
+		for y := range input.greys {
+			input.greys[y] = img.Pix[y*img.Width : (y+1)*img.Width]
+		}
 	}
+	io.channels.input <- input
 
 	fmt.Println("File", filename, "input done!")
 }
@@ -140,9 +177,18 @@ func startIo(p Params, c ioChannels) {
 			case ioInput:
 				io.readPgmImage()
 			case ioOutput:
-				io.writePgmImage()
+				if io.params.Format == FormatPNG {
+					io.writePngImage()
+				} else {
+					io.writePgmImage()
+				}
+			case ioRecord:
+				io.recordFrame()
+			case ioSaveRecording:
+				io.saveRecording()
 			case ioCheckIdle:
-				io.channels.idle <- true
+				io.channels.idle <- io.err
+				io.err = nil
 			}
 		}
 	}
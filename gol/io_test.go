@@ -0,0 +1,111 @@
+package gol
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// runEvents runs p locally and returns every event it sends until the events channel is closed
+func runEvents(p Params) []Event {
+	p.Local = true
+	events := make(chan Event, 1000)
+	go Run(p, events, nil)
+	var all []Event
+	for event := range events {
+		all = append(all, event)
+	}
+	return all
+}
+
+// failure returns the Failed event among events and whether the final turn was reported before it
+func failure(events []Event) (failed *Failed, final bool) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case FinalTurnComplete:
+			final = true
+		case Failed:
+			failed = &e
+		}
+	}
+	return failed, final
+}
+
+// tempDir makes a directory for a test, which the returned function removes
+func tempDir(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "gol")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir, func() {
+		_ = os.RemoveAll(dir)
+	}
+}
+
+// TestBadInput checks that a missing, corrupt or wrongly sized input image fails the run without reporting a
+// final turn or writing any images
+func TestBadInput(t *testing.T) {
+	dir, remove := tempDir(t)
+	defer remove()
+	corrupt := filepath.Join(dir, "corrupt.pgm")
+	small := filepath.Join(dir, "small.pgm")
+	if err := ioutil.WriteFile(corrupt, []byte("P5\n16 16\n255\nshort"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(small, append([]byte("P5\n8 8\n255\n"), make([]byte, 64)...), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, input := range []string{filepath.Join(dir, "missing.pgm"), corrupt, small} {
+		out := filepath.Join(dir, "out")
+		p := Params{Turns: 1, ImageWidth: 16, ImageHeight: 16, Input: input, OutDir: out}
+		failed, final := failure(runEvents(p))
+		if failed == nil || final {
+			t.Errorf("%s: failed is %v and final turn reported is %v, want a failure instead of a final turn", input, failed, final)
+		}
+		if _, err := os.Stat(out); !os.IsNotExist(err) {
+			t.Errorf("%s: wrote images to %s", input, out)
+		}
+	}
+}
+
+// TestBadOutput checks that the run fails after its final turn when the image cannot be written
+func TestBadOutput(t *testing.T) {
+	dir, remove := tempDir(t)
+	defer remove()
+	input := filepath.Join(dir, "in.pgm")
+	if err := ioutil.WriteFile(input, append([]byte("P5\n16 16\n255\n"), make([]byte, 256)...), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// the output directory is a file, so nothing can be created inside it
+	p := Params{Turns: 1, ImageWidth: 16, ImageHeight: 16, Input: input, OutDir: input}
+	if failed, final := failure(runEvents(p)); failed == nil || !final {
+		t.Errorf("failed is %v and final turn reported is %v, want both", failed, final)
+	}
+}
+
+// TestThreshold checks that only the cells of the input image above the threshold are alive
+func TestThreshold(t *testing.T) {
+	dir, remove := tempDir(t)
+	defer remove()
+	input := filepath.Join(dir, "in.pgm")
+	pixels := make([]byte, 256)
+	pixels[1*16+1], pixels[2*16+2], pixels[3*16+3] = 1, 100, 200
+	if err := ioutil.WriteFile(input, append([]byte("P5\n16 16\n255\n"), pixels...), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for threshold, want := range map[uint8]int{0: 3, 1: 2, 150: 1, 200: 0} {
+		p := Params{Turns: 0, ImageWidth: 16, ImageHeight: 16, Input: input, OutDir: dir, Threshold: threshold}
+		alive := -1
+		for _, event := range runEvents(p) {
+			if e, ok := event.(FinalTurnComplete); ok {
+				alive = len(e.Alive)
+			}
+		}
+		if alive != want {
+			t.Errorf("threshold %d left %d cells alive, want %d", threshold, alive, want)
+		}
+	}
+}
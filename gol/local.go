@@ -0,0 +1,74 @@
+package gol
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+	"uk.ac.bris.cs/gameoflife/hashlife"
+	"uk.ac.bris.cs/gameoflife/rule"
+	"uk.ac.bris.cs/gameoflife/util"
+)
+
+// runLocalHashLife runs the GoL in this process with the HashLife engine, without needing a broker.
+// Edits and viewports need the broker, so they are ignored
+func runLocalHashLife(p Params, c distributorChannels, keyPresses <-chan rune, edits <-chan Edit, views <-chan Viewport) {
+	timer := time.NewTimer(2 * time.Second)
+	filename := strconv.Itoa(p.ImageWidth) + "x" + strconv.Itoa(p.ImageHeight)
+	world, err := loadWorld(p, c)
+	if err != nil {
+		fail(c, 0, err)
+		return
+	}
+
+	if p.Boundary != util.Torus || !rule.IsLife(p.Rule) {
+		fail(c, 0, fmt.Errorf("hashlife only supports Life on the torus boundary"))
+		return
+	}
+	universe, err := hashlife.New(world)
+	if err != nil {
+		fail(c, 0, err)
+		return
+	}
+
+	c.events <- WorkersConnected{Workers: 0}
+	if p.Record.On(0) {
+		recordFrame(p, c, world, nil)
+	}
+	for universe.Turn() < p.Turns {
+		select {
+		case k := <-keyPresses:
+			switch k {
+			case 's':
+				outputWorld(p, universe.Turn(), universe.World(), filename, c)
+			case 'q', 'k': // there are no workers or broker to kill, so k behaves like q
+				exit(p, c, universe.Turn(), universe.World(), nil, filename)
+				return
+			case 'p':
+				fmt.Println("#PAUSED\nCompleted Turns", universe.Turn())
+				c.events <- StateChange{universe.Turn(), Paused}
+				for k = 0; k != 'p'; {
+					select {
+					case k = <-keyPresses:
+					case <-edits: // drained so that the window does not block, as they are ignored
+					case <-views:
+					}
+				}
+				fmt.Println("#CONTINUING")
+				c.events <- StateChange{universe.Turn(), Executing}
+			}
+		case <-edits:
+			fmt.Println("#EDITS NEED A BROKER")
+		case <-views:
+		case <-timer.C:
+			c.events <- AliveCellsCount{CellsCount: universe.AliveCount(), CompletedTurns: universe.Turn()}
+			timer.Reset(2 * time.Second)
+		default:
+			next := p.Record.Next(universe.Turn(), p.Turns)
+			universe.Jump(next - universe.Turn())
+			if universe.Turn() == next && p.Record.On(next) {
+				recordFrame(p, c, universe.World(), nil)
+			}
+		}
+	}
+	exit(p, c, universe.Turn(), universe.World(), nil, filename)
+}
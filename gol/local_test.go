@@ -0,0 +1,33 @@
+package gol
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"uk.ac.bris.cs/gameoflife/util"
+)
+
+// TestLocalRefused checks that a run HashLife cannot do locally fails without reporting a final turn or writing an
+// empty image
+func TestLocalRefused(t *testing.T) {
+	dir, remove := tempDir(t)
+	defer remove()
+	input := filepath.Join(dir, "in.pgm")
+	if err := ioutil.WriteFile(input, append([]byte("P5\n16 16\n255\n"), make([]byte, 256)...), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	out := filepath.Join(dir, "out")
+	for _, p := range []Params{
+		{Turns: 1, ImageWidth: 16, ImageHeight: 16, Input: input, OutDir: out, Boundary: util.Reflect},
+		{Turns: 1, ImageWidth: 16, ImageHeight: 16, Input: input, OutDir: out, Rule: "B36/S23"},
+	} {
+		if failed, final := failure(runEvents(p)); failed == nil || final {
+			t.Errorf("%v %s: failed is %v and final turn reported is %v, want a failure instead of a final turn", p.Boundary, p.Rule, failed, final)
+		}
+		if _, err := os.Stat(out); !os.IsNotExist(err) {
+			t.Errorf("%v %s: wrote images to %s", p.Boundary, p.Rule, out)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package gol
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"uk.ac.bris.cs/gameoflife/rule"
+)
+
+// DefaultTemplate names each snapshot after the size of the world and the turn it was taken on
+const DefaultTemplate = "{width}x{height}x{turn}"
+
+// timeLayout is how the {time} placeholder is written, which keeps the names sortable
+const timeLayout = "20060102-150405"
+
+// placeholders are the names that may appear in braces in an output template
+var placeholders = []string{"width", "height", "turn", "rule", "time", "seed"}
+
+// CheckTemplate returns an error if the output template is empty, has unmatched braces or has an unknown placeholder
+func CheckTemplate(template string) error {
+	if template == "" {
+		return fmt.Errorf("output template should not be empty")
+	}
+	rest := template
+	for {
+		open := strings.IndexAny(rest, "{}")
+		if open < 0 {
+			return nil
+		}
+		if rest[open] == '}' {
+			return fmt.Errorf("output template %q has a } without a {", template)
+		}
+		end := strings.IndexByte(rest[open:], '}')
+		if end < 0 {
+			return fmt.Errorf("output template %q has a { without a }", template)
+		}
+		name := rest[open+1 : open+end]
+		known := false
+		for _, placeholder := range placeholders {
+			known = known || name == placeholder
+		}
+		if !known {
+			return fmt.Errorf("output template %q has unknown placeholder {%s}, expected one of {%s}", template, name, strings.Join(placeholders, "}, {"))
+		}
+		rest = rest[open+end+1:]
+	}
+}
+
+// inputPath returns the image the world is loaded from
+func (p Params) inputPath() string {
+	if p.Input != "" {
+		return p.Input
+	}
+	return filepath.Join("images", strconv.Itoa(p.ImageWidth)+"x"+strconv.Itoa(p.ImageHeight)+".pgm")
+}
+
+// outputName fills in the output template for a snapshot of the given turn, or range of turns for a recording.
+// The seed is that of the soup, or 0 when the world was read from an image. The rule is written in its canonical form with / replaced so that it stays a single path element
+func (p Params) outputName(turn string) string {
+	template := p.Template
+	if template == "" {
+		template = DefaultTemplate
+	}
+	ruleName := rule.Life
+	if r, err := rule.Parse(p.Rule); err == nil {
+		ruleName = r.String()
+	}
+	return strings.NewReplacer(
+		"{width}", strconv.Itoa(p.ImageWidth),
+		"{height}", strconv.Itoa(p.ImageHeight),
+		"{turn}", turn,
+		"{rule}", strings.Replace(ruleName, "/", "_", -1),
+		"{time}", p.Started.Format(timeLayout),
+		"{seed}", strconv.FormatInt(p.Soup.Seed, 10),
+	).Replace(template)
+}
+
+// createOutput creates the file for an output name inside the output directory, along with any directories the
+// template puts it in
+func (p Params) createOutput(name, extension string) (*os.File, error) {
+	dir := p.OutDir
+	if dir == "" {
+		dir = "out"
+	}
+	path := filepath.Join(dir, name+"."+extension)
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return nil, err
+	}
+	return os.Create(path)
+}
@@ -0,0 +1,39 @@
+package gol
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestOutputName checks that the default names are unchanged and that every placeholder is filled in
+func TestOutputName(t *testing.T) {
+	p := Params{ImageWidth: 64, ImageHeight: 32}
+	if name := p.outputName("100"); name != "64x32x100" {
+		t.Errorf("default name is %q, want 64x32x100", name)
+	}
+	if path := p.inputPath(); path != filepath.Join("images", "64x32.pgm") {
+		t.Errorf("default input is %q, want images/64x32.pgm", path)
+	}
+
+	p.Rule = "highlife"
+	p.Template = "{rule}/{time}-{turn}-{width}"
+	p.Started = time.Date(2024, 3, 7, 9, 5, 1, 0, time.UTC)
+	if name := p.outputName("0-10"); name != "B36_S23/20240307-090501-0-10-64" {
+		t.Errorf("templated name is %q", name)
+	}
+}
+
+// TestCheckTemplate checks that templates with unknown placeholders or unmatched braces are refused
+func TestCheckTemplate(t *testing.T) {
+	for _, good := range []string{DefaultTemplate, "glider", "{rule}/{time}/{turn}"} {
+		if err := CheckTemplate(good); err != nil {
+			t.Errorf("CheckTemplate(%q) = %v", good, err)
+		}
+	}
+	for _, bad := range []string{"", "{turns}", "{width", "width}", "{}"} {
+		if err := CheckTemplate(bad); err == nil {
+			t.Errorf("CheckTemplate(%q) should fail", bad)
+		}
+	}
+}
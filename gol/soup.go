@@ -0,0 +1,120 @@
+package gol
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+	"uk.ac.bris.cs/gameoflife/pnm"
+)
+
+// Symmetries a soup can be given, named as in Catagolue. C1 has no symmetry, C2 and C4 are rotations by a half and
+// a quarter turn, D2 is a mirror through the vertical axis, D4 mirrors through both axes and D8 adds the diagonals
+var symmetries = []string{"C1", "C2", "C4", "D2", "D4", "D8"}
+
+// Soup is a random world used instead of an input image. Each cell is alive with probability Density, and the
+// same Seed always gives the same world. A Density of 0 means the world is read from the input image
+type Soup struct {
+	Density  float64
+	Seed     int64
+	Seeded   bool // whether Seed was given, as 0 is a seed like any other
+	Symmetry string
+}
+
+// ParseSoup parses a soup written as density:seed:symmetry, where the seed and symmetry may be left out for a
+// seed chosen when the run starts and no symmetry. An empty string reads the world from the input image instead
+func ParseSoup(s string) (Soup, error) {
+	if s == "" {
+		return Soup{}, nil
+	}
+	parts := strings.Split(s, ":")
+	if len(parts) > 3 {
+		return Soup{}, fmt.Errorf("soup %q should be density:seed:symmetry", s)
+	}
+	soup := Soup{Symmetry: "C1"}
+	var err error
+	if soup.Density, err = strconv.ParseFloat(parts[0], 64); err != nil || soup.Density <= 0 || soup.Density > 1 {
+		return Soup{}, fmt.Errorf("soup %q should have a density above 0 and at most 1", s)
+	}
+	if len(parts) > 1 && parts[1] != "" {
+		if soup.Seed, err = strconv.ParseInt(parts[1], 10, 64); err != nil {
+			return Soup{}, fmt.Errorf("soup %q should have a whole number as its seed", s)
+		}
+		soup.Seeded = true
+	}
+	if len(parts) > 2 {
+		soup.Symmetry = strings.ToUpper(parts[2])
+	}
+	known := false
+	for _, symmetry := range symmetries {
+		known = known || soup.Symmetry == symmetry
+	}
+	if !known {
+		return Soup{}, fmt.Errorf("soup %q has unknown symmetry %s, expected one of %s", s, soup.Symmetry, strings.Join(symmetries, ", "))
+	}
+	return soup, nil
+}
+
+// chooseSeed gives a soup that was not given a seed one from the time t, so that the seed it records repeats the run
+func (s *Soup) chooseSeed(t time.Time) {
+	if s.Density > 0 && !s.Seeded {
+		s.Seed, s.Seeded = t.UnixNano(), true
+	}
+}
+
+// String writes the soup in the form ParseSoup reads, so that it can be recorded alongside the output
+func (s Soup) String() string {
+	return fmt.Sprintf("%g:%d:%s", s.Density, s.Seed, s.Symmetry)
+}
+
+// images returns the cells that the symmetry maps x, y to in a width by height world, including x, y itself.
+// Rotations by a quarter turn and diagonal mirrors only apply to square worlds, and are left out of others
+func (s Soup) images(x, y, width, height int) [][2]int {
+	mx, my := width-1-x, height-1-y
+	images := [][2]int{{x, y}}
+	switch s.Symmetry {
+	case "C2":
+		images = append(images, [2]int{mx, my})
+	case "C4":
+		images = append(images, [2]int{mx, my})
+		if width == height {
+			images = append(images, [2]int{my, x}, [2]int{y, mx})
+		}
+	case "D2":
+		images = append(images, [2]int{mx, y})
+	case "D4":
+		images = append(images, [2]int{mx, y}, [2]int{x, my}, [2]int{mx, my})
+	case "D8":
+		images = append(images, [2]int{mx, y}, [2]int{x, my}, [2]int{mx, my})
+		if width == height {
+			images = append(images, [2]int{y, x}, [2]int{my, x}, [2]int{y, mx}, [2]int{my, mx})
+		}
+	}
+	return images
+}
+
+// Generate returns a width by height soup where alive cells have the grey level alive. Every cell is drawn from
+// the seeded source, then takes the value drawn for the first cell, in row order, that the symmetry maps it to
+func (s Soup) Generate(width, height int, alive uint8) *pnm.Image {
+	random := rand.New(rand.NewSource(s.Seed))
+	drawn := make([]bool, width*height)
+	for i := range drawn {
+		drawn[i] = random.Float64() < s.Density
+	}
+	img := &pnm.Image{Width: width, Height: height, Pix: make([]uint8, width*height)}
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			first := y*width + x
+			for _, image := range s.images(x, y, width, height) {
+				if i := image[1]*width + image[0]; i < first {
+					first = i
+				}
+			}
+			if drawn[first] {
+				img.Pix[y*width+x] = alive
+			}
+		}
+	}
+	return img
+}
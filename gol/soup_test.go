@@ -0,0 +1,70 @@
+package gol
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+// TestSoup checks that soups are reproducible from their seed, near their density and have their symmetry
+func TestSoup(t *testing.T) {
+	soup, err := ParseSoup("0.3:42")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, b := soup.Generate(64, 48, 255), soup.Generate(64, 48, 255)
+	if !bytes.Equal(a.Pix, b.Pix) {
+		t.Fatal("the same seed gave different soups")
+	}
+	if alive := len(a.Cells(0)); alive < 64*48*25/100 || alive > 64*48*35/100 {
+		t.Errorf("a soup of density 0.3 has %d of %d cells alive", alive, 64*48)
+	}
+	soup.Seed++
+	if bytes.Equal(a.Pix, soup.Generate(64, 48, 255).Pix) {
+		t.Error("different seeds gave the same soup")
+	}
+
+	for _, symmetry := range symmetries {
+		for _, size := range [][2]int{{32, 32}, {32, 16}} {
+			soup, err := ParseSoup("0.5:7:" + symmetry)
+			if err != nil {
+				t.Fatal(err)
+			}
+			w, h := size[0], size[1]
+			img := soup.Generate(w, h, 255)
+			for y := 0; y < h; y++ {
+				for x := 0; x < w; x++ {
+					for _, image := range soup.images(x, y, w, h) {
+						if img.At(x, y) != img.At(image[0], image[1]) {
+							t.Fatalf("%s soup of %dx%d differs between %d,%d and %v", symmetry, w, h, x, y, image)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	for _, bad := range []string{"0", "1.5", "x", "0.5:x", "0.5:1:C3", "0.5:1:C1:1"} {
+		if _, err := ParseSoup(bad); err == nil {
+			t.Errorf("ParseSoup(%q) should fail", bad)
+		}
+	}
+	if soup, _ := ParseSoup("0.5:9:d4"); soup.String() != "0.5:9:D4" {
+		t.Errorf("soup is written as %q, want 0.5:9:D4", soup.String())
+	}
+}
+
+// TestChooseSeed checks that only a soup without a seed is given one from the time, and that a seed of 0 is kept
+func TestChooseSeed(t *testing.T) {
+	start := time.Unix(0, 12345)
+	for s, want := range map[string]int64{"0.5:0": 0, "0.5:7": 7, "0.5": 12345, "0.5::D2": 12345} {
+		soup, err := ParseSoup(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		soup.chooseSeed(start)
+		if soup.Seed != want || !soup.Seeded {
+			t.Errorf("the soup %q has seed %d, want %d", s, soup.Seed, want)
+		}
+	}
+}
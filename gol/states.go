@@ -0,0 +1,68 @@
+package gol
+
+import (
+	"strconv"
+	"uk.ac.bris.cs/gameoflife/rule"
+	"uk.ac.bris.cs/gameoflife/util"
+)
+
+// paramsRule returns the rule being run, which has already been checked when the world was loaded
+func paramsRule(p Params) rule.Rule {
+	r, err := rule.Parse(p.Rule)
+	util.Check(err)
+	return r
+}
+
+// loadStates reads the initial world in through the io goroutine, mapping each grey level to the closest state
+func loadStates(p Params, c distributorChannels, r rule.Rule) ([]util.StateArray, error) {
+	bits := util.BitsForStates(r.States())
+	world := make([]util.StateArray, p.ImageHeight)
+	c.ioCommand <- ioInput
+	c.ioFilename <- p.inputPath()
+	input := <-c.ioInput
+	if input.err != nil {
+		return nil, input.err
+	}
+	greys := input.greys
+	for y := range world {
+		world[y] = util.NewStateArray(p.ImageWidth, bits)
+		for x := 0; x < p.ImageWidth; x++ {
+			world[y].Set(x, rule.FromGrey(r, greys[y][x]))
+		}
+	}
+	return world, nil
+}
+
+// stateGreys maps each state of the world to a grey level for the io goroutine
+func stateGreys(p Params, world []util.StateArray) ioImage {
+	r := paramsRule(p)
+	greys := make([][]uint8, p.ImageHeight)
+	for y := range greys {
+		greys[y] = make([]uint8, p.ImageWidth)
+		for x := range greys[y] {
+			greys[y][x] = rule.Grey(r, world[y].Get(x))
+		}
+	}
+	return ioImage{greys: greys}
+}
+
+// outputStates sends the whole world to the io goroutine to be written out, mapping each state to a grey level
+func outputStates(p Params, turn int, world []util.StateArray, filename string, c distributorChannels) {
+	c.ioCommand <- ioOutput
+	c.ioFilename <- p.outputName(strconv.Itoa(turn))
+	c.ioOutput <- stateGreys(p, world)
+	c.events <- ImageOutputComplete{CompletedTurns: turn, Filename: filename}
+}
+
+// finalAliveStates gives the coordinates of all the cells that are not dead, including those that are dying
+func finalAliveStates(world []util.StateArray) []util.Cell {
+	var aliveCells []util.Cell
+	for y, row := range world {
+		for x := 0; x < row.Len(); x++ {
+			if row.Get(x) != 0 {
+				aliveCells = append(aliveCells, util.Cell{X: x, Y: y})
+			}
+		}
+	}
+	return aliveCells
+}
@@ -0,0 +1,41 @@
+package gol
+
+import (
+	"fmt"
+	"uk.ac.bris.cs/gameoflife/stubs"
+	"uk.ac.bris.cs/gameoflife/util"
+)
+
+// Viewport is the rectangle of the world that the window shows, in cells, sent to the distributor whenever the
+// window is zoomed or panned so that only that part of the world has to be fetched from the broker
+type Viewport struct {
+	X, Y, Width, Height int
+}
+
+// wholeWorld returns the viewport that shows all of the world
+func wholeWorld(p Params) Viewport {
+	return Viewport{Width: p.ImageWidth, Height: p.ImageHeight}
+}
+
+// showWorld brings the part of the window inside the viewport up to date with the current world, by sending a
+// CellFlipped event for every cell that is different to what the window shows and then a TurnComplete event so
+// that it is drawn. shown is what the window shows, and is updated to match. Cells in any state but dead are
+// shown as alive
+func showWorld(client stubs.Caller, c distributorChannels, shown []util.BitArray, view Viewport) {
+	region := new(stubs.RegionResponse)
+	request := stubs.RegionRequest{X: view.X, Y: view.Y, Width: view.Width, Height: view.Height}
+	if err := client.Call(stubs.GetRegion, request, region); err != nil {
+		fmt.Println(err)
+		return
+	}
+	for i, row := range region.Rows {
+		y := region.Y + i
+		for x := region.X; x < region.X+row.Len() && x < shown[y].Len(); x++ {
+			if alive := row.GetBit(x - region.X); alive != shown[y].GetBit(x) {
+				shown[y].SetBit(x, alive)
+				c.events <- CellFlipped{CompletedTurns: region.CompletedTurns, Cell: util.Cell{X: x, Y: y}}
+			}
+		}
+	}
+	c.events <- TurnComplete{CompletedTurns: region.CompletedTurns}
+}
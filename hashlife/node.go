@@ -0,0 +1,203 @@
+package hashlife
+
+// node is a square of 2^level by 2^level cells. Nodes are immutable and canonical, so two nodes with the same
+// cells are the same pointer, which lets the result of a node be remembered and reused wherever it appears
+type node struct {
+	level          uint
+	nw, ne, sw, se *node
+	alive          bool           // only used by the leaves at level 0
+	population     int            // number of alive cells in the node
+	result         *node          // the centre of the node after 2^(level-2) generations, once computed
+	steps          map[uint]*node // the centre of the node after 2^j generations for smaller j, once computed
+}
+
+// quad is the key used to find the canonical node with the given children
+type quad struct {
+	nw, ne, sw, se *node
+}
+
+// cache holds the canonical nodes and remembers their results
+type cache struct {
+	dead, live *node
+	nodes      map[quad]*node
+	empty      []*node // empty[level] is the node of that level with no alive cells
+}
+
+// newCache creates a cache with just the two leaves
+func newCache() *cache {
+	c := &cache{
+		dead:  &node{level: 0},
+		live:  &node{level: 0, alive: true, population: 1},
+		nodes: make(map[quad]*node),
+	}
+	c.empty = []*node{c.dead}
+	return c
+}
+
+// leaf returns the canonical leaf for a cell
+func (c *cache) leaf(alive bool) *node {
+	if alive {
+		return c.live
+	}
+	return c.dead
+}
+
+// join returns the canonical node with the given children, which must all be of the same level
+func (c *cache) join(nw, ne, sw, se *node) *node {
+	key := quad{nw, ne, sw, se}
+	if n, ok := c.nodes[key]; ok {
+		return n
+	}
+	n := &node{
+		level:      nw.level + 1,
+		nw:         nw,
+		ne:         ne,
+		sw:         sw,
+		se:         se,
+		population: nw.population + ne.population + sw.population + se.population,
+	}
+	c.nodes[key] = n
+	return n
+}
+
+// emptyNode returns the node of the given level with no alive cells
+func (c *cache) emptyNode(level uint) *node {
+	for uint(len(c.empty)) <= level {
+		smaller := c.empty[len(c.empty)-1]
+		c.empty = append(c.empty, c.join(smaller, smaller, smaller, smaller))
+	}
+	return c.empty[level]
+}
+
+// intern copies a node from another cache into this one, so that the other cache can be thrown away
+func (c *cache) intern(n *node, seen map[*node]*node) *node {
+	if n.level == 0 {
+		return c.leaf(n.alive)
+	}
+	if interned, ok := seen[n]; ok {
+		return interned
+	}
+	interned := c.join(c.intern(n.nw, seen), c.intern(n.ne, seen), c.intern(n.sw, seen), c.intern(n.se, seen))
+	seen[n] = interned
+	return interned
+}
+
+// cell returns whether the cell x, y of a node is alive
+func (n *node) cell(x, y int) bool {
+	for n.level > 0 {
+		half := 1 << (n.level - 1)
+		switch {
+		case x < half && y < half:
+			n = n.nw
+		case y < half:
+			n, x = n.ne, x-half
+		case x < half:
+			n, y = n.sw, y-half
+		default:
+			n, x, y = n.se, x-half, y-half
+		}
+	}
+	return n.alive
+}
+
+// nextCell applies the GoL rules to a cell given whether it is alive and its number of alive neighbours
+func nextCell(alive bool, neighbours int) bool {
+	if alive {
+		return neighbours == 2 || neighbours == 3
+	}
+	return neighbours == 3
+}
+
+// base computes the centre 2x2 of a 4x4 node after one generation
+func (c *cache) base(n *node) *node {
+	var cells [4][4]bool
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 4; x++ {
+			cells[y][x] = n.cell(x, y)
+		}
+	}
+	var next [2][2]bool
+	for y := 1; y <= 2; y++ {
+		for x := 1; x <= 2; x++ {
+			neighbours := 0
+			for dy := -1; dy <= 1; dy++ {
+				for dx := -1; dx <= 1; dx++ {
+					if (dx != 0 || dy != 0) && cells[y+dy][x+dx] {
+						neighbours++
+					}
+				}
+			}
+			next[y-1][x-1] = nextCell(cells[y][x], neighbours)
+		}
+	}
+	return c.join(c.leaf(next[0][0]), c.leaf(next[0][1]), c.leaf(next[1][0]), c.leaf(next[1][1]))
+}
+
+// centre returns the middle half of a node, without advancing it
+func (c *cache) centre(n *node) *node {
+	return c.join(n.nw.se, n.ne.sw, n.sw.ne, n.se.nw)
+}
+
+// successor returns the centre half of a node after 2^j generations, j must be at most level-2
+func (c *cache) successor(n *node, j uint) *node {
+	if n.population == 0 {
+		return c.emptyNode(n.level - 1)
+	}
+	full := j == n.level-2
+	if full && n.result != nil {
+		return n.result
+	}
+	if !full {
+		if result, ok := n.steps[j]; ok {
+			return result
+		}
+	}
+
+	var result *node
+	if n.level == 2 {
+		result = c.base(n)
+	} else {
+		// the nine overlapping nodes of the level below that make up n
+		n00 := n.nw
+		n01 := c.join(n.nw.ne, n.ne.nw, n.nw.se, n.ne.sw)
+		n02 := n.ne
+		n10 := c.join(n.nw.sw, n.nw.se, n.sw.nw, n.sw.ne)
+		n11 := c.join(n.nw.se, n.ne.sw, n.sw.ne, n.se.nw)
+		n12 := c.join(n.ne.sw, n.ne.se, n.se.nw, n.se.ne)
+		n20 := n.sw
+		n21 := c.join(n.sw.ne, n.se.nw, n.sw.se, n.se.sw)
+		n22 := n.se
+
+		// a full step advances each half of the way, a smaller step only advances once
+		first := func(m *node) *node {
+			if full {
+				return c.successor(m, j-1)
+			}
+			return c.centre(m)
+		}
+		second := j
+		if full {
+			second = j - 1
+		}
+		c00, c01, c02 := first(n00), first(n01), first(n02)
+		c10, c11, c12 := first(n10), first(n11), first(n12)
+		c20, c21, c22 := first(n20), first(n21), first(n22)
+
+		result = c.join(
+			c.successor(c.join(c00, c01, c10, c11), second),
+			c.successor(c.join(c01, c02, c11, c12), second),
+			c.successor(c.join(c10, c11, c20, c21), second),
+			c.successor(c.join(c11, c12, c21, c22), second),
+		)
+	}
+
+	if full {
+		n.result = result
+	} else {
+		if n.steps == nil {
+			n.steps = make(map[uint]*node)
+		}
+		n.steps[j] = result
+	}
+	return result
+}
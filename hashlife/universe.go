@@ -0,0 +1,213 @@
+// Package hashlife advances a Game of Life world using HashLife, a memoized quadtree that can jump ahead
+// 2^k generations at once and quickly repeats any part of the world that it has seen before.
+package hashlife
+
+import (
+	"errors"
+	"uk.ac.bris.cs/gameoflife/util"
+)
+
+// maxNodes is the number of canonical nodes kept before the cache is rebuilt from only the live nodes, a variable so
+// that tests can collect sooner
+var maxNodes = 4000000
+
+// Universe is a toroidal world being advanced by HashLife.
+// The world is tiled to a square of 2^level cells, and two by two copies of that square are advanced together,
+// so that the centre of the result is the wrapped around world
+type Universe struct {
+	width, height int
+	level         uint
+	cache         *cache
+	root          *node
+	initial       *node
+	turn          int
+	seen          map[*node]int // the turn on which each root was last seen, to find when the world repeats
+	period        int           // the number of turns after which the world repeats, 0 if not yet known
+}
+
+// isPowerOfTwo reports whether n is a positive power of two
+func isPowerOfTwo(n int) bool {
+	return n > 0 && n&(n-1) == 0
+}
+
+// New creates a Universe at turn 0 from a world. The width and height must both be powers of two
+func New(world []util.BitArray) (*Universe, error) {
+	if len(world) == 0 {
+		return nil, errors.New("hashlife: world is empty")
+	}
+	width, height := world[0].Len(), len(world)
+	if !isPowerOfTwo(width) || !isPowerOfTwo(height) {
+		return nil, errors.New("hashlife: width and height must be powers of two")
+	}
+	size := width
+	if height > size {
+		size = height
+	}
+	level := uint(0)
+	for 1<<level < size {
+		level++
+	}
+	if level < 1 {
+		level = 1
+	}
+
+	u := &Universe{
+		width:  width,
+		height: height,
+		level:  level,
+		cache:  newCache(),
+		seen:   make(map[*node]int),
+	}
+	u.root = u.build(world, 0, 0, level)
+	u.initial = u.root
+	return u, nil
+}
+
+// build creates the node of the given level whose top left cell is x, y, wrapping around the world
+func (u *Universe) build(world []util.BitArray, x, y int, level uint) *node {
+	if level == 0 {
+		return u.cache.leaf(world[y%u.height].GetBit(x % u.width))
+	}
+	half := 1 << (level - 1)
+	return u.cache.join(
+		u.build(world, x, y, level-1),
+		u.build(world, x+half, y, level-1),
+		u.build(world, x, y+half, level-1),
+		u.build(world, x+half, y+half, level-1),
+	)
+}
+
+// Turn returns the number of turns that have been completed
+func (u *Universe) Turn() int {
+	return u.turn
+}
+
+// AliveCount returns the number of alive cells in the world
+func (u *Universe) AliveCount() int {
+	size := 1 << u.level
+	return u.root.population * u.width * u.height / (size * size)
+}
+
+// World returns the current state of the world
+func (u *Universe) World() []util.BitArray {
+	world := make([]util.BitArray, u.height)
+	for y := range world {
+		world[y] = util.NewBitArray(u.width)
+	}
+	u.fill(world, u.root, 0, 0)
+	return world
+}
+
+// fill sets the alive cells of a node whose top left cell is x, y, skipping anything outside the world
+func (u *Universe) fill(world []util.BitArray, n *node, x, y int) {
+	if n.population == 0 || x >= u.width || y >= u.height {
+		return
+	}
+	if n.level == 0 {
+		world[y].SetBit(x, true)
+		return
+	}
+	half := 1 << (n.level - 1)
+	u.fill(world, n.nw, x, y)
+	u.fill(world, n.ne, x+half, y)
+	u.fill(world, n.sw, x, y+half)
+	u.fill(world, n.se, x+half, y+half)
+}
+
+// MaxJump returns the largest number of turns that a single call to Jump can advance without a known period
+func (u *Universe) MaxJump() int {
+	return 1 << (u.level - 1)
+}
+
+// Jump advances the world by up to limit turns in one piece of work, and returns how many turns it advanced.
+// Once the world is found to repeat, whole periods are skipped without any work
+func (u *Universe) Jump(limit int) int {
+	if limit <= 0 {
+		return 0
+	}
+	if u.period == 0 {
+		if turn, ok := u.seen[u.root]; ok {
+			u.period = u.turn - turn
+		}
+	}
+	if u.period > 0 && limit >= u.period {
+		skipped := limit - limit%u.period
+		u.turn += skipped
+		return skipped
+	}
+
+	u.seen[u.root] = u.turn
+	j := uint(0)
+	for j+1 <= u.level-1 && 1<<(j+1) <= limit {
+		j++
+	}
+	u.root = u.advance(u.root, j)
+	u.turn += 1 << j
+
+	if len(u.cache.nodes) > maxNodes {
+		u.collect()
+	}
+	return 1 << j
+}
+
+// Advance moves the world on by the given number of turns
+func (u *Universe) Advance(turns int) {
+	for turns > 0 {
+		turns -= u.Jump(turns)
+	}
+}
+
+// advance returns the wrapped around world after 2^j generations
+func (u *Universe) advance(root *node, j uint) *node {
+	c := u.cache
+	// the result of the doubled world is its centre, which is the world shifted by half its size in each direction
+	r := c.successor(c.join(root, root, root, root), j)
+	return c.join(r.se, r.sw, r.ne, r.nw)
+}
+
+// collect throws away every remembered result by moving the live nodes to a new cache
+func (u *Universe) collect() {
+	old := u.cache
+	u.cache = newCache()
+	interned := make(map[*node]*node)
+	u.root = u.cache.intern(u.root, interned)
+	u.initial = u.cache.intern(u.initial, interned)
+	u.seen = make(map[*node]int)
+	old.nodes = nil
+}
+
+// Clone returns a copy of the universe with a cache of its own. This universe must not be advanced while it is being
+// cloned, but after that either can be advanced while the other is
+func (u *Universe) Clone() *Universe {
+	copied := *u
+	copied.cache = newCache()
+	interned := make(map[*node]*node)
+	copied.root = copied.cache.intern(u.root, interned)
+	copied.initial = copied.cache.intern(u.initial, interned)
+	copied.seen = make(map[*node]int)
+	return &copied
+}
+
+// Seek moves the universe to the given turn, going back to turn 0 first if the turn has already passed
+func (u *Universe) Seek(turn int) error {
+	if turn < 0 {
+		return errors.New("hashlife: turn must not be negative")
+	}
+	if turn < u.turn {
+		u.root = u.initial
+		u.turn = 0
+		u.period = 0
+		u.seen = make(map[*node]int)
+	}
+	u.Advance(turn - u.turn)
+	return nil
+}
+
+// At returns a copy of the universe at the given turn, leaving this one unchanged
+func (u *Universe) At(turn int) (*Universe, error) {
+	copied := u.Clone()
+	if err := copied.Seek(turn); err != nil {
+		return nil, err
+	}
+	return copied, nil
+}
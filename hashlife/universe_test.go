@@ -0,0 +1,127 @@
+package hashlife
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+	"testing"
+	"uk.ac.bris.cs/gameoflife/pnm"
+	"uk.ac.bris.cs/gameoflife/util"
+)
+
+// readWorld loads a pgm image as a world
+func readWorld(path string) []util.BitArray {
+	img, err := pnm.ReadFile(path)
+	util.Check(err)
+	world := make([]util.BitArray, img.Height)
+	for y := range world {
+		world[y] = util.NewBitArray(img.Width)
+		for x := 0; x < img.Width; x++ {
+			world[y].SetBitFromUint8(x, img.At(x, y))
+		}
+	}
+	return world
+}
+
+// readAliveCounts loads the expected number of alive cells on each turn
+func readAliveCounts(path string) map[int]int {
+	f, err := os.Open(path)
+	util.Check(err)
+	defer f.Close()
+	table, err := csv.NewReader(f).ReadAll()
+	util.Check(err)
+	alive := make(map[int]int)
+	for _, row := range table[1:] {
+		turn, _ := strconv.Atoi(row[0])
+		count, _ := strconv.Atoi(row[1])
+		alive[turn] = count
+	}
+	return alive
+}
+
+func assertEqualWorld(t *testing.T, given, expected []util.BitArray) {
+	for y := range expected {
+		for x := 0; x < expected[y].Len(); x++ {
+			if given[y].GetBit(x) != expected[y].GetBit(x) {
+				t.Fatalf("cell (%d, %d) differs", x, y)
+			}
+		}
+	}
+}
+
+// TestUniverse checks the worlds against the check images on 0, 1 and 100 turns
+func TestUniverse(t *testing.T) {
+	for _, size := range []int{16, 64, 512} {
+		for _, turns := range []int{0, 1, 100} {
+			t.Run(fmt.Sprintf("%dx%dx%d", size, size, turns), func(t *testing.T) {
+				u, err := New(readWorld(fmt.Sprintf("../images/%dx%d.pgm", size, size)))
+				if err != nil {
+					t.Fatal(err)
+				}
+				u.Advance(turns)
+				if u.Turn() != turns {
+					t.Fatalf("expected turn %d, got %d", turns, u.Turn())
+				}
+				assertEqualWorld(t, u.World(), readWorld(fmt.Sprintf("../check/images/%dx%dx%d.pgm", size, size, turns)))
+			})
+		}
+	}
+}
+
+// TestAt checks the alive counts at arbitrary turns, including long after the world has started repeating
+func TestAt(t *testing.T) {
+	alive := readAliveCounts("../check/alive/512x512.csv")
+	u, err := New(readWorld("../images/512x512.pgm"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, turn := range []int{37, 1, 1000, 999, 10000, 10000000000, 10000000001} {
+		at, err := u.At(turn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected, ok := alive[turn]
+		if !ok {
+			expected = alive[10000-turn%2]
+		}
+		if at.AliveCount() != expected {
+			t.Errorf("at turn %d expected %d alive cells, got %d", turn, expected, at.AliveCount())
+		}
+		u = at
+	}
+}
+
+// TestAtCollect checks that a copy made by At collecting its cache leaves the cache of the universe it was copied
+// from alone, so that the original can carry on and reach the same world
+func TestAtCollect(t *testing.T) {
+	defer func(n int) { maxNodes = n }(maxNodes)
+	maxNodes = 2000
+	u, err := New(readWorld("../images/64x64.pgm"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	u.Advance(10)
+	at, err := u.At(500)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u.Advance(490)
+	assertEqualWorld(t, u.World(), at.World())
+	expected, err := New(readWorld("../images/64x64.pgm"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for expected.Turn() < 500 {
+		expected.Jump(1)
+	}
+	assertEqualWorld(t, u.World(), expected.World())
+}
+
+// TestNotPowerOfTwo checks that worlds HashLife cannot wrap around are refused
+func TestNotPowerOfTwo(t *testing.T) {
+	world := []util.BitArray{util.NewBitArray(24), util.NewBitArray(24)}
+	if _, err := New(world); err == nil {
+		t.Error("expected an error for a 24x2 world")
+	}
+}
@@ -3,10 +3,15 @@ package main
 import (
 	"flag"
 	"fmt"
+	"os"
 	"runtime"
 
 	"uk.ac.bris.cs/gameoflife/gol"
+	"uk.ac.bris.cs/gameoflife/rule"
 	"uk.ac.bris.cs/gameoflife/sdl"
+	"uk.ac.bris.cs/gameoflife/stubs"
+	"uk.ac.bris.cs/gameoflife/tui"
+	"uk.ac.bris.cs/gameoflife/util"
 )
 
 // main is the function called when starting Game of Life with 'go run .'
@@ -38,6 +43,113 @@ func main() {
 		10000000000,
 		"Specify the number of turns to process. Defaults to 10000000000.")
 
+	flag.StringVar(
+		&params.Engine,
+		"engine",
+		stubs.EngineStep,
+		"Specify the engine the broker uses, step or hashlife. Defaults to step.")
+
+	flag.BoolVar(
+		&params.Local,
+		"local",
+		false,
+		"Runs HashLife in this process instead of connecting to a broker.")
+
+	flag.BoolVar(
+		&params.GRPC,
+		"grpc",
+		false,
+		"Calls the broker over gRPC instead of net/rpc, for a broker started with -grpc.")
+
+	boundary := flag.String(
+		"boundary",
+		util.Torus.String(),
+		"Specify how the edges of the world are joined: torus, dead, reflect, klein or cross. Defaults to torus.")
+
+	flag.StringVar(
+		&params.Rule,
+		"rule",
+		rule.Life,
+		"Specify the rule in B/S/C notation with an optional H or V neighbourhood suffix, Hensel notation such as B2-a/S12, Larger than Life notation such as R5,C0,M1,S34..58,B34..45,NM, or by name such as highlife, bosco or wireworld. Defaults to B3/S23.")
+
+	flag.StringVar(
+		&params.Format,
+		"format",
+		gol.FormatPGM,
+		"Specify the format of saved images, pgm or png. Defaults to pgm.")
+
+	flag.IntVar(
+		&params.Scale,
+		"scale",
+		1,
+		"Specify the width in pixels of each cell in png and gif images. Defaults to 1.")
+
+	alive := flag.String(
+		"alive",
+		"#ffffff",
+		"Specify the colour of alive cells in png and gif images. Defaults to #ffffff.")
+
+	dead := flag.String(
+		"dead",
+		"#000000",
+		"Specify the colour of dead cells in png and gif images. Defaults to #000000.")
+
+	record := flag.String(
+		"record",
+		"",
+		"Records every Nth turn from start to end into an animated gif, given as start:end:N. Defaults to no recording.")
+
+	flag.StringVar(
+		&params.Input,
+		"in",
+		"",
+		"Specify the pgm image to load the world from. Defaults to images/<width>x<height>.pgm.")
+
+	threshold := flag.Uint(
+		"threshold",
+		0,
+		"Specify the grey level that a cell of the input image must be above to be alive in Life. Defaults to 0.")
+
+	flag.StringVar(
+		&params.OutDir,
+		"out",
+		"out",
+		"Specify the directory that images are written to. Defaults to out.")
+
+	flag.StringVar(
+		&params.Template,
+		"name",
+		gol.DefaultTemplate,
+		"Specify the name of each image written, where {width}, {height}, {turn}, {rule}, {time} and {seed} are filled in. Defaults to "+gol.DefaultTemplate+".")
+
+	soup := flag.String(
+		"soup",
+		"",
+		"Starts from a random soup instead of the input image, given as density:seed:symmetry where the symmetry is C1, C2, C4, D2, D4 or D8, such as 0.5:42:D4. Defaults to no soup.")
+
+	flag.StringVar(
+		&params.Pattern,
+		"pattern",
+		"",
+		"Specify an RLE file of a pattern to drop under the cursor with the right mouse button. Defaults to no pattern.")
+
+	flag.StringVar(
+		&params.Colouring,
+		"colour",
+		sdl.ColourPlain,
+		"Specify how the window colours cells: plain, age or change. Defaults to plain.")
+
+	flag.StringVar(
+		&params.Palette,
+		"palette",
+		sdl.Palettes[0],
+		"Specify the palette for colouring cells by age: heat, viridis, ocean or grey. Defaults to "+sdl.Palettes[0]+".")
+
+	useTUI := flag.Bool(
+		"tui",
+		false,
+		"Shows the world in the terminal instead of an SDL window, for machines without a display.")
+
 	noVis := flag.Bool(
 		"noVis",
 		false,
@@ -45,24 +157,94 @@ func main() {
 
 	flag.Parse()
 
+	var err error
+	if params.Boundary, err = util.ParseBoundary(*boundary); err != nil {
+		fmt.Println(err)
+		return
+	}
+	if _, err = rule.Parse(params.Rule); err != nil {
+		fmt.Println(err)
+		return
+	}
+	if params.Format != gol.FormatPGM && params.Format != gol.FormatPNG {
+		fmt.Printf("unknown format %q, expected pgm or png\n", params.Format)
+		return
+	}
+	if params.Alive, err = gol.ParseColour(*alive); err != nil {
+		fmt.Println(err)
+		return
+	}
+	if params.Dead, err = gol.ParseColour(*dead); err != nil {
+		fmt.Println(err)
+		return
+	}
+	if params.Record, err = gol.ParseRecording(*record); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	if *threshold > 254 {
+		fmt.Println("threshold should be a grey level from 0 to 254")
+		return
+	}
+	params.Threshold = uint8(*threshold)
+
+	if params.Soup, err = gol.ParseSoup(*soup); err != nil {
+		fmt.Println(err)
+		return
+	}
+	if err = gol.CheckTemplate(params.Template); err != nil {
+		fmt.Println(err)
+		return
+	}
+	if err = sdl.CheckColours(params.Colouring, params.Palette); err != nil {
+		fmt.Println(err)
+		return
+	}
+
 	fmt.Println("Threads:", params.Threads)
 	fmt.Println("Width:", params.ImageWidth)
 	fmt.Println("Height:", params.ImageHeight)
 
 	keyPresses := make(chan rune, 10)
+	edits := make(chan gol.Edit, 10)
+	views := make(chan gol.Viewport, 10)
 	events := make(chan gol.Event, 1000)
 
+	failure := make(chan error, 1)
+	if *useTUI {
+		params.Views = views
+	} else if !(*noVis) {
+		params.Edits, params.Views = edits, views
+	}
 	go gol.Run(params, events, keyPresses)
-	if !(*noVis) {
-		sdl.Run(params, events, keyPresses)
-	} else {
-		complete := false
-		for !complete {
-			event := <-events
-			switch event.(type) {
-			case gol.FinalTurnComplete:
-				complete = true
+	shown := watchFailure(events, failure)
+	if *useTUI {
+		tui.Run(params, shown, keyPresses)
+	} else if !(*noVis) {
+		sdl.Run(params, shown, keyPresses, edits, views)
+	}
+	// the rest of the run is waited for, so that its images are written before the program exits
+	for range shown {
+	}
+	if err := <-failure; err != nil {
+		os.Exit(1)
+	}
+}
+
+// watchFailure passes on every event until the run is over, then sends the error of any Failed event to failure
+func watchFailure(events <-chan gol.Event, failure chan<- error) <-chan gol.Event {
+	shown := make(chan gol.Event, cap(events))
+	go func() {
+		var err error
+		for event := range events {
+			if e, ok := event.(gol.Failed); ok {
+				err = e.Err
 			}
+			shown <- event
 		}
-	}
+		close(shown)
+		failure <- err
+	}()
+	return shown
 }
@@ -0,0 +1,259 @@
+// Package pnm reads the Netpbm image formats the game of life worlds are stored in: plain and raw PBM (P1 and P4)
+// and plain and raw PGM (P2 and P5), with comments and any maxval.
+package pnm
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"uk.ac.bris.cs/gameoflife/util"
+)
+
+// Image is a decoded image, with every sample scaled to a grey level between 0 and 255
+type Image struct {
+	Width, Height int
+	Pix           []uint8 // the grey level of each pixel, row by row
+}
+
+// At returns the grey level of the pixel x, y
+func (img *Image) At(x, y int) uint8 {
+	return img.Pix[y*img.Width+x]
+}
+
+// Cells returns the pixels with a grey level above the threshold. A threshold of 0 makes any pixel that is not
+// black alive, as the worlds are read
+func (img *Image) Cells(threshold uint8) []util.Cell {
+	var cells []util.Cell
+	for y := 0; y < img.Height; y++ {
+		for x := 0; x < img.Width; x++ {
+			if img.At(x, y) > threshold {
+				cells = append(cells, util.Cell{X: x, Y: y})
+			}
+		}
+	}
+	return cells
+}
+
+// ReadFile decodes the image in a file
+func ReadFile(path string) (*Image, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	img, err := Decode(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return img, nil
+}
+
+// decoder reads the header and samples of an image one byte at a time
+type decoder struct {
+	r *bufio.Reader
+}
+
+// Decode reads an image. PBM pixels that are set, which are drawn black by other programs, are given the grey
+// level 255 as they are alive. Samples of a PGM are scaled from 0 to maxval to 0 to 255, except that a sample
+// that is not 0 is never scaled down to 0, so that any pixel that is not black stays alive whatever the maxval
+func Decode(r io.Reader) (*Image, error) {
+	d := decoder{r: bufio.NewReader(r)}
+	magic := make([]byte, 2)
+	if _, err := io.ReadFull(d.r, magic); err != nil {
+		return nil, fmt.Errorf("reading the magic number: %v", err)
+	}
+	format := string(magic)
+	if format != "P1" && format != "P2" && format != "P4" && format != "P5" {
+		return nil, fmt.Errorf("%q is not a PBM or PGM magic number", format)
+	}
+
+	img := &Image{}
+	var err error
+	if img.Width, err = d.header("width"); err != nil {
+		return nil, err
+	}
+	if img.Height, err = d.header("height"); err != nil {
+		return nil, err
+	}
+	if img.Width <= 0 || img.Height <= 0 {
+		return nil, fmt.Errorf("the size %dx%d is empty", img.Width, img.Height)
+	}
+	maxval := 1
+	if format == "P2" || format == "P5" {
+		if maxval, err = d.header("maxval"); err != nil {
+			return nil, err
+		}
+		if maxval < 1 || maxval > 65535 {
+			return nil, fmt.Errorf("maxval %d should be between 1 and 65535", maxval)
+		}
+	}
+	// a single whitespace byte separates the header of a raw image from its samples
+	if format == "P4" || format == "P5" {
+		if b, err := d.r.ReadByte(); err != nil || !isSpace(b) {
+			return nil, fmt.Errorf("the header should end with a single whitespace character")
+		}
+	}
+
+	img.Pix = make([]uint8, img.Width*img.Height)
+	switch format {
+	case "P1":
+		err = d.plainBits(img)
+	case "P2":
+		err = d.plainSamples(img, maxval)
+	case "P4":
+		err = d.rawBits(img)
+	case "P5":
+		err = d.rawSamples(img, maxval)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return img, nil
+}
+
+func isSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r' || b == '\v' || b == '\f'
+}
+
+// skip moves past whitespace and comments, which run from # to the end of the line
+func (d decoder) skip() error {
+	for {
+		b, err := d.r.ReadByte()
+		if err != nil {
+			return err
+		}
+		switch {
+		case b == '#':
+			if _, err := d.r.ReadString('\n'); err != nil {
+				return err
+			}
+		case !isSpace(b):
+			return d.r.UnreadByte()
+		}
+	}
+}
+
+// number reads a decimal number, after any whitespace and comments
+func (d decoder) number() (int, error) {
+	if err := d.skip(); err != nil {
+		return 0, err
+	}
+	n, digits := 0, 0
+	for {
+		b, err := d.r.ReadByte()
+		if err == io.EOF && digits > 0 {
+			return n, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		if b < '0' || b > '9' {
+			if digits == 0 {
+				return 0, fmt.Errorf("expected a number but found %q", b)
+			}
+			return n, d.r.UnreadByte()
+		}
+		if n > 1<<24 {
+			return 0, fmt.Errorf("number is too large")
+		}
+		n = n*10 + int(b-'0')
+		digits++
+	}
+}
+
+// header reads a number of the header, naming it in any error
+func (d decoder) header(name string) (int, error) {
+	n, err := d.number()
+	if err != nil {
+		return 0, fmt.Errorf("reading the %s: %v", name, err)
+	}
+	return n, nil
+}
+
+// scale maps a sample from 0 to maxval onto 0 to 255, keeping samples that are not 0 above 0
+func scale(sample, maxval int) uint8 {
+	if sample == 0 {
+		return 0
+	}
+	grey := sample * 255 / maxval
+	if grey == 0 {
+		return 1
+	}
+	return uint8(grey)
+}
+
+// plainBits reads the samples of a P1 image, which are 0 or 1 and need not be separated
+func (d decoder) plainBits(img *Image) error {
+	for i := range img.Pix {
+		if err := d.skip(); err != nil {
+			return fmt.Errorf("reading pixel %d: %v", i, err)
+		}
+		b, _ := d.r.ReadByte()
+		switch b {
+		case '0':
+		case '1':
+			img.Pix[i] = 255
+		default:
+			return fmt.Errorf("pixel %d should be 0 or 1 but is %q", i, b)
+		}
+	}
+	return nil
+}
+
+// plainSamples reads the decimal samples of a P2 image
+func (d decoder) plainSamples(img *Image, maxval int) error {
+	for i := range img.Pix {
+		sample, err := d.number()
+		if err != nil {
+			return fmt.Errorf("reading pixel %d: %v", i, err)
+		}
+		if sample > maxval {
+			return fmt.Errorf("pixel %d is %d, more than the maxval %d", i, sample, maxval)
+		}
+		img.Pix[i] = scale(sample, maxval)
+	}
+	return nil
+}
+
+// rawBits reads the packed rows of a P4 image, where each row starts on a new byte
+func (d decoder) rawBits(img *Image) error {
+	row := make([]byte, (img.Width+7)/8)
+	for y := 0; y < img.Height; y++ {
+		if _, err := io.ReadFull(d.r, row); err != nil {
+			return fmt.Errorf("reading row %d: %v", y, err)
+		}
+		for x := 0; x < img.Width; x++ {
+			if row[x/8]&(0x80>>uint(x%8)) != 0 {
+				img.Pix[y*img.Width+x] = 255
+			}
+		}
+	}
+	return nil
+}
+
+// rawSamples reads the binary samples of a P5 image, which take two bytes each, most significant first, when the
+// maxval is more than 255
+func (d decoder) rawSamples(img *Image, maxval int) error {
+	size := 1
+	if maxval > 255 {
+		size = 2
+	}
+	buffer := make([]byte, img.Width*size)
+	for y := 0; y < img.Height; y++ {
+		if _, err := io.ReadFull(d.r, buffer); err != nil {
+			return fmt.Errorf("reading row %d: %v", y, err)
+		}
+		for x := 0; x < img.Width; x++ {
+			sample := int(buffer[x*size])
+			if size == 2 {
+				sample = sample<<8 | int(buffer[x*size+1])
+			}
+			if sample > maxval {
+				return fmt.Errorf("pixel %d, %d is %d, more than the maxval %d", x, y, sample, maxval)
+			}
+			img.Pix[y*img.Width+x] = scale(sample, maxval)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package pnm
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"uk.ac.bris.cs/gameoflife/util"
+)
+
+// glider is the grey levels of a 4x3 image of a glider in the top left, which every test image below decodes to
+var glider = []uint8{
+	0, 255, 0, 0,
+	0, 0, 255, 0,
+	255, 255, 255, 0,
+}
+
+// TestDecode checks each format, with comments in the header and samples that are whitespace bytes
+func TestDecode(t *testing.T) {
+	tests := map[string]string{
+		"P1":                 "P1\n# a glider\n4 3\n0 1 0 0\n0 0 1 0\n1 1 1 0\n",
+		"P1 without spaces":  "P1 4 3 0100\n0010\n1110",
+		"P2":                 "P2\n4 3\n# comments may come anywhere in the header\n15\n0 15 0 0 0 0 15 0 15 15 15 0\n",
+		"P4":                 "P4\n4 3\n\x40\x20\xe0",
+		"P5":                 "P5\n4 3\n255\n\x00\xff\x00\x00\x00\x00\xff\x00\xff\xff\xff\x00",
+		"P5 with a comment":  "P5 #size follows\n4#width\n3 255\n\x00\xff\x00\x00\x00\x00\xff\x00\xff\xff\xff\x00",
+		"P5 with 16 bits":    "P5\n4 3\n65535\n\x00\x00\xff\xff\x00\x00\x00\x00\x00\x00\x00\x00\xff\xff\x00\x00\xff\xff\xff\xff\xff\xff\x00\x00",
+		"P5 with maxval one": "P5 4 3 1\n\x00\x01\x00\x00\x00\x00\x01\x00\x01\x01\x01\x00",
+	}
+	for name, data := range tests {
+		img, err := Decode(strings.NewReader(data))
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		if img.Width != 4 || img.Height != 3 {
+			t.Errorf("%s: size is %dx%d, want 4x3", name, img.Width, img.Height)
+			continue
+		}
+		for i := range glider {
+			if img.Pix[i] != glider[i] {
+				t.Errorf("%s: pixel %d is %d, want %d", name, i, img.Pix[i], glider[i])
+				break
+			}
+		}
+	}
+}
+
+// TestWhitespaceSamples checks raw samples that are whitespace or # bytes, which splitting the file into fields
+// used to break
+func TestWhitespaceSamples(t *testing.T) {
+	samples := "\x09\x0a\x20#\x0d\x00"
+	img, err := Decode(strings.NewReader("P5\n6 1\n255\n" + samples))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range samples {
+		if img.Pix[i] != samples[i] {
+			t.Errorf("pixel %d is %d, want %d", i, img.Pix[i], samples[i])
+		}
+	}
+}
+
+// TestScale checks that samples are scaled to grey levels, and that small samples of a large maxval stay alive
+func TestScale(t *testing.T) {
+	img, err := Decode(strings.NewReader("P2 4 1 1000 0 1 500 1000"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []uint8{0, 1, 127, 255}
+	for i := range want {
+		if img.Pix[i] != want[i] {
+			t.Errorf("sample %d is grey level %d, want %d", i, img.Pix[i], want[i])
+		}
+	}
+	cells := img.Cells(0)
+	if len(cells) != 3 || cells[0] != (util.Cell{X: 1, Y: 0}) {
+		t.Errorf("alive cells are %v, want the last three", cells)
+	}
+	if cells := img.Cells(127); len(cells) != 1 {
+		t.Errorf("alive cells above 127 are %v, want the last one", cells)
+	}
+}
+
+// TestErrors checks that malformed images give errors rather than panicking
+func TestErrors(t *testing.T) {
+	for _, data := range []string{
+		"",
+		"P6\n1 1\n255\n\x00\x00\x00",
+		"P5\n4\n",
+		"P5\nx 3\n255\n",
+		"P5\n0 3\n255\n",
+		"P5\n4 3\n0\n",
+		"P5\n4 3\n70000\n",
+		"P5\n4 3\n255\n\x00\x00",
+		"P5\n1 1\n15\n\x10",
+		"P5\n1 1\n255",
+		"P2\n2 1\n15\n3",
+		"P2\n2 1\n15\n3 16",
+		"P1\n2 1\n0 2",
+		"P4\n9 1\n\x00",
+	} {
+		if _, err := Decode(strings.NewReader(data)); err == nil {
+			t.Errorf("%q should fail", data)
+		}
+	}
+}
+
+// TestFixtures checks that every image the tests use decodes at the size in its name
+func TestFixtures(t *testing.T) {
+	for _, size := range []int{16, 64, 512} {
+		img, err := ReadFile(fmt.Sprintf("../images/%dx%d.pgm", size, size))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if img.Width != size || img.Height != size || len(img.Cells(0)) == 0 {
+			t.Errorf("%dx%d.pgm is %dx%d with %d alive cells", size, size, img.Width, img.Height, len(img.Cells(0)))
+		}
+	}
+}
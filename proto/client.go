@@ -0,0 +1,192 @@
+package proto
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"io"
+	"net/rpc"
+	"sync"
+	"time"
+	"uk.ac.bris.cs/gameoflife/stubs"
+)
+
+// maxMessage is the largest message sent or received, as the default of 4MB is less than a 5120x5120 world
+const maxMessage = 1 << 30
+
+// dialTimeout is how long Dial waits for the server to answer, since gRPC would otherwise keep trying
+const dialTimeout = 5 * time.Second
+
+// NewServer returns a gRPC server that accepts the same size of world as a Client sends
+func NewServer() *grpc.Server {
+	return grpc.NewServer(grpc.MaxRecvMsgSize(maxMessage), grpc.MaxSendMsgSize(maxMessage))
+}
+
+// Client makes the calls of stubs to a broker or a worker over gRPC, as a stubs.Caller. The stubs.Worker calls are
+// made one after another over a StreamStrips stream that is kept open between them
+type Client struct {
+	conn   *grpc.ClientConn
+	broker BrokerClient
+	worker WorkerClient
+	mutex  sync.Mutex                // held for each call over the stream, so that each response is read by its request
+	strips Worker_StreamStripsClient // opened by the first stubs.Worker call and after one fails
+	cancel context.CancelFunc        // ends the stream
+}
+
+// Dial connects to a broker or a worker serving gRPC at address
+func Dial(address string) (*Client, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dialTimeout)
+	defer cancel()
+	conn, err := grpc.DialContext(ctx, address, grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock(),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(maxMessage), grpc.MaxCallSendMsgSize(maxMessage)))
+	if err != nil {
+		return nil, fmt.Errorf("dialing %s over gRPC: %v", address, err)
+	}
+	return &Client{conn: conn, broker: NewBrokerClient(conn), worker: NewWorkerClient(conn)}, nil
+}
+
+// DialCaller connects to a broker or a worker at address over gRPC when overGRPC is set, or over net/rpc otherwise
+func DialCaller(address string, overGRPC bool) (stubs.Caller, error) {
+	if overGRPC {
+		client, err := Dial(address)
+		if err != nil {
+			return nil, err
+		}
+		return client, nil
+	}
+	client, err := rpc.Dial("tcp", address)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// Broker returns the generated client of the broker, for the calls such as WatchTurns that net/rpc cannot make
+func (c *Client) Broker() BrokerClient {
+	return c.broker
+}
+
+// Call makes the call of stubs named by serviceMethod, with args and reply of the types stubs declares for it.
+// Replies of other types are left as they are, as with net/rpc when a response is decoded into an empty struct
+func (c *Client) Call(serviceMethod string, args interface{}, reply interface{}) (err error) {
+	ctx := context.Background()
+	switch serviceMethod {
+	case stubs.BrokerHello, stubs.WorkerHello:
+		var res *Hello
+		if serviceMethod == stubs.BrokerHello {
+			res, err = c.broker.Hello(ctx, FromHello(args.(stubs.Hello)))
+		} else {
+			res, err = c.worker.Hello(ctx, FromHello(args.(stubs.Hello)))
+		}
+		if r, ok := reply.(*stubs.Hello); ok && err == nil {
+			*r = ToHello(res)
+		}
+	case stubs.RunGameOfLife:
+		var res *Response
+		res, err = c.broker.RunGameOfLife(ctx, FromRequest(args.(stubs.Request)))
+		if r, ok := reply.(*stubs.Response); ok && err == nil {
+			world, states := ToWorld(res.GetNextWorld())
+			*r = stubs.Response{NextWorld: world, NextStates: states, CompletedTurns: int(res.GetCompletedTurns())}
+		}
+	case stubs.GetAliveCount, stubs.GetAliveCountAt, stubs.SetRegion, stubs.StampPattern, stubs.ClearWorld:
+		var res *AliveCellsResponse
+		switch serviceMethod {
+		case stubs.GetAliveCount:
+			res, err = c.broker.GetAliveCount(ctx, &Empty{})
+		case stubs.GetAliveCountAt:
+			res, err = c.broker.GetAliveCountAt(ctx, &AliveCountAtRequest{Turn: int64(args.(stubs.AliveCountAtRequest).Turn)})
+		case stubs.SetRegion:
+			res, err = c.broker.SetRegion(ctx, FromRegionRequest(args.(stubs.RegionRequest)))
+		case stubs.StampPattern:
+			stamp := args.(stubs.StampRequest)
+			res, err = c.broker.StampPattern(ctx, &StampRequest{X: int32(stamp.X), Y: int32(stamp.Y), Rle: stamp.RLE})
+		default:
+			res, err = c.broker.ClearWorld(ctx, &Empty{})
+		}
+		if r, ok := reply.(*stubs.AliveCellsResponse); ok && err == nil {
+			*r = stubs.AliveCellsResponse{AliveCellsCount: int(res.GetAliveCellsCount()), CompletedTurns: int(res.GetCompletedTurns())}
+		}
+	case stubs.GetCurrentWorld:
+		var res *CurrentWorldResponse
+		res, err = c.broker.GetCurrentWorld(ctx, &Empty{})
+		if r, ok := reply.(*stubs.CurrentWorldResponse); ok && err == nil {
+			world, states := ToWorld(res.GetWorld())
+			*r = stubs.CurrentWorldResponse{World: world, States: states, CompletedTurns: int(res.GetCompletedTurns())}
+		}
+	case stubs.HaltTurns:
+		_, err = c.broker.HaltTurns(ctx, &Empty{})
+	case stubs.KillClients:
+		_, err = c.broker.KillClients(ctx, &Empty{})
+	case stubs.PauseServer:
+		var res *PauseServerResponse
+		res, err = c.broker.PauseServer(ctx, &Empty{})
+		if r, ok := reply.(*stubs.PauseServerResponse); ok && err == nil {
+			r.CompletedTurns = int(res.GetCompletedTurns())
+		}
+	case stubs.GetWorkerStats:
+		var res *WorkerStatsResponse
+		res, err = c.broker.GetWorkerStats(ctx, &Empty{})
+		if r, ok := reply.(*stubs.WorkerStatsResponse); ok && err == nil {
+			*r = ToWorkerStats(res)
+		}
+	case stubs.GetRegion:
+		var res *RegionResponse
+		res, err = c.broker.GetRegion(ctx, FromRegionRequest(args.(stubs.RegionRequest)))
+		if r, ok := reply.(*stubs.RegionResponse); ok && err == nil {
+			*r = ToRegionResponse(res)
+		}
+	case stubs.Worker:
+		var res *WorkerResponse
+		res, err = c.stream(FromWorkerRequest(args.(stubs.WorkerRequest)))
+		if r, ok := reply.(*stubs.WorkerResponse); ok && err == nil {
+			*r = ToWorkerResponse(res)
+		}
+	case stubs.KillWorker:
+		_, err = c.worker.KillWorker(ctx, &Empty{})
+	default:
+		err = fmt.Errorf("%s cannot be called over gRPC", serviceMethod)
+	}
+	return err
+}
+
+// stream sends req over the StreamStrips stream and returns its response, opening the stream if there is none.
+// A stream that fails is ended, and the next call opens another
+func (c *Client) stream(req *WorkerRequest) (*WorkerResponse, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.strips == nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		strips, err := c.worker.StreamStrips(ctx)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		c.strips, c.cancel = strips, cancel
+	}
+	var res *WorkerResponse
+	err := c.strips.Send(req)
+	if err == nil {
+		res, err = c.strips.Recv()
+	} else if err == io.EOF {
+		// the worker ended the stream, and the reason is only given to Recv
+		_, err = c.strips.Recv()
+	}
+	if err != nil {
+		c.cancel()
+		c.strips, c.cancel = nil, nil
+		return nil, err
+	}
+	return res, nil
+}
+
+// Close ends the StreamStrips stream if there is one and closes the connection
+func (c *Client) Close() error {
+	c.mutex.Lock()
+	if c.cancel != nil {
+		c.cancel()
+		c.strips, c.cancel = nil, nil
+	}
+	c.mutex.Unlock()
+	return c.conn.Close()
+}
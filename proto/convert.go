@@ -0,0 +1,243 @@
+package proto
+
+import (
+	"uk.ac.bris.cs/gameoflife/stubs"
+	"uk.ac.bris.cs/gameoflife/util"
+)
+
+// This file converts between the structs of stubs and the messages generated from gameoflife.proto, so that both
+// ends of a call can carry on working with stubs whichever transport the call was made over
+
+// FromBits returns the rows of a Life world as a message, or nil when there are none
+func FromBits(world []util.BitArray) *BitRows {
+	if world == nil {
+		return nil
+	}
+	rows := make([][]byte, len(world))
+	for i, row := range world {
+		rows[i] = row
+	}
+	return &BitRows{Rows: rows}
+}
+
+// ToBits returns the rows of a Life world held by a message, or nil when there are none
+func ToBits(rows *BitRows) []util.BitArray {
+	if rows == nil {
+		return nil
+	}
+	world := make([]util.BitArray, len(rows.Rows))
+	for i, row := range rows.Rows {
+		world[i] = row
+	}
+	return world
+}
+
+// FromStates returns the rows of a world with more than two states as a message, or nil when there are none
+func FromStates(states []util.StateArray) *StateRows {
+	if states == nil {
+		return nil
+	}
+	rows := &StateRows{Rows: make([][]byte, len(states))}
+	for i, row := range states {
+		rows.Bits, rows.Rows[i] = uint32(row.Bits), row.Data
+	}
+	return rows
+}
+
+// ToStates returns the rows of a world with more than two states held by a message, or nil when there are none
+func ToStates(rows *StateRows) []util.StateArray {
+	if rows == nil {
+		return nil
+	}
+	states := make([]util.StateArray, len(rows.Rows))
+	for i, row := range rows.Rows {
+		states[i] = util.StateArray{Bits: uint8(rows.Bits), Data: row}
+	}
+	return states
+}
+
+// FromWorld returns a world as a message, in states when there are any and otherwise in cells
+func FromWorld(world []util.BitArray, states []util.StateArray) *World {
+	if states != nil {
+		return &World{World: &World_States{States: FromStates(states)}}
+	}
+	if world != nil {
+		return &World{World: &World_Cells{Cells: FromBits(world)}}
+	}
+	return nil
+}
+
+// ToWorld returns the world held by a message, one of which is nil
+func ToWorld(w *World) ([]util.BitArray, []util.StateArray) {
+	return ToBits(w.GetCells()), ToStates(w.GetStates())
+}
+
+// FromHello returns h as a message
+func FromHello(h stubs.Hello) *Hello {
+	return &Hello{Version: int32(h.Version), MinVersion: int32(h.MinVersion), Rules: h.Rules, Encodings: h.Encodings,
+		Features: h.Features}
+}
+
+// ToHello returns the Hello held by a message
+func ToHello(h *Hello) stubs.Hello {
+	return stubs.Hello{Version: int(h.GetVersion()), MinVersion: int(h.GetMinVersion()), Rules: h.GetRules(),
+		Encodings: h.GetEncodings(), Features: h.GetFeatures()}
+}
+
+// FromRequest returns req as a message
+func FromRequest(req stubs.Request) *Request {
+	return &Request{
+		Turns:       int64(req.Turns),
+		ImageWidth:  int32(req.ImageWidth),
+		ImageHeight: int32(req.ImageHeight),
+		World:       FromWorld(req.World, req.States),
+		Resume:      req.Resume,
+		Engine:      req.Engine,
+		Boundary:    Boundary(req.Boundary),
+		Rule:        req.Rule,
+		Threads:     int32(req.Threads),
+	}
+}
+
+// ToRequest returns the Request held by a message
+func ToRequest(req *Request) stubs.Request {
+	world, states := ToWorld(req.GetWorld())
+	return stubs.Request{
+		Turns:       int(req.GetTurns()),
+		ImageWidth:  int(req.GetImageWidth()),
+		ImageHeight: int(req.GetImageHeight()),
+		World:       world,
+		Resume:      req.GetResume(),
+		Engine:      req.GetEngine(),
+		Boundary:    util.Boundary(req.GetBoundary()),
+		Rule:        req.GetRule(),
+		States:      states,
+		Threads:     int(req.GetThreads()),
+	}
+}
+
+// FromRegionRequest returns req as a message
+func FromRegionRequest(req stubs.RegionRequest) *RegionRequest {
+	return &RegionRequest{X: int32(req.X), Y: int32(req.Y), Width: int32(req.Width), Height: int32(req.Height),
+		State: uint32(req.State)}
+}
+
+// ToRegionRequest returns the RegionRequest held by a message
+func ToRegionRequest(req *RegionRequest) stubs.RegionRequest {
+	return stubs.RegionRequest{X: int(req.GetX()), Y: int(req.GetY()), Width: int(req.GetWidth()),
+		Height: int(req.GetHeight()), State: uint8(req.GetState())}
+}
+
+// FromRegionResponse returns res as a message
+func FromRegionResponse(res stubs.RegionResponse) *RegionResponse {
+	return &RegionResponse{X: int32(res.X), Y: int32(res.Y), Rows: FromBits(res.Rows),
+		CompletedTurns: int64(res.CompletedTurns)}
+}
+
+// ToRegionResponse returns the RegionResponse held by a message
+func ToRegionResponse(res *RegionResponse) stubs.RegionResponse {
+	return stubs.RegionResponse{X: int(res.GetX()), Y: int(res.GetY()), Rows: ToBits(res.GetRows()),
+		CompletedTurns: int(res.GetCompletedTurns())}
+}
+
+// FromWorkerStats returns res as a message
+func FromWorkerStats(res stubs.WorkerStatsResponse) *WorkerStatsResponse {
+	split := make([]int32, len(res.Split))
+	for i, rows := range res.Split {
+		split[i] = int32(rows)
+	}
+	return &WorkerStatsResponse{Split: split, NsPerRow: res.NsPerRow, CompletedTurns: int64(res.CompletedTurns),
+		Workers: int32(res.Workers)}
+}
+
+// ToWorkerStats returns the WorkerStatsResponse held by a message
+func ToWorkerStats(res *WorkerStatsResponse) stubs.WorkerStatsResponse {
+	split := make([]int, len(res.GetSplit()))
+	for i, rows := range res.GetSplit() {
+		split[i] = int(rows)
+	}
+	return stubs.WorkerStatsResponse{Split: split, NsPerRow: res.GetNsPerRow(),
+		CompletedTurns: int(res.GetCompletedTurns()), Workers: int(res.GetWorkers())}
+}
+
+// fromTiles returns tiles as messages
+func fromTiles(tiles []util.Tile) []*Tile {
+	out := make([]*Tile, len(tiles))
+	for i, tile := range tiles {
+		out[i] = &Tile{X: int32(tile.X), Y: int32(tile.Y), Rows: FromBits(tile.Rows)}
+	}
+	return out
+}
+
+// toTiles returns the tiles held by messages, or nil when there are none
+func toTiles(tiles []*Tile) []util.Tile {
+	if len(tiles) == 0 {
+		return nil
+	}
+	out := make([]util.Tile, len(tiles))
+	for i, tile := range tiles {
+		out[i] = util.Tile{X: int(tile.GetX()), Y: int(tile.GetY()), Rows: ToBits(tile.GetRows())}
+	}
+	return out
+}
+
+// FromWorkerRequest returns req as a message
+func FromWorkerRequest(req stubs.WorkerRequest) *WorkerRequest {
+	active := make([]*TileIndex, len(req.ActiveTiles))
+	for i, tile := range req.ActiveTiles {
+		active[i] = &TileIndex{X: int32(tile.X), Y: int32(tile.Y)}
+	}
+	return &WorkerRequest{
+		Scale:       int32(req.Scale),
+		WorldWidth:  int32(req.WorldWidth),
+		InPart:      FromBits(req.InPart),
+		Sparse:      req.Sparse,
+		StartY:      int32(req.StartY),
+		ActiveTiles: active,
+		Boundary:    Boundary(req.Boundary),
+		West:        req.West,
+		East:        req.East,
+		Rule:        req.Rule,
+		InStates:    FromStates(req.InStates),
+		WestStates:  req.WestStates,
+		EastStates:  req.EastStates,
+		Threads:     int32(req.Threads),
+		Halos:       fromTiles(req.Halos),
+	}
+}
+
+// ToWorkerRequest returns the WorkerRequest held by a message
+func ToWorkerRequest(req *WorkerRequest) stubs.WorkerRequest {
+	var active []util.TileIndex
+	for _, tile := range req.GetActiveTiles() {
+		active = append(active, util.TileIndex{X: int(tile.GetX()), Y: int(tile.GetY())})
+	}
+	return stubs.WorkerRequest{
+		Scale:       int(req.GetScale()),
+		WorldWidth:  int(req.GetWorldWidth()),
+		InPart:      ToBits(req.GetInPart()),
+		Sparse:      req.GetSparse(),
+		StartY:      int(req.GetStartY()),
+		ActiveTiles: active,
+		Halos:       toTiles(req.GetHalos()),
+		Boundary:    util.Boundary(req.GetBoundary()),
+		West:        req.GetWest(),
+		East:        req.GetEast(),
+		Rule:        req.GetRule(),
+		InStates:    ToStates(req.GetInStates()),
+		WestStates:  req.GetWestStates(),
+		EastStates:  req.GetEastStates(),
+		Threads:     int(req.GetThreads()),
+	}
+}
+
+// FromWorkerResponse returns res as a message
+func FromWorkerResponse(res stubs.WorkerResponse) *WorkerResponse {
+	return &WorkerResponse{OutPart: FromBits(res.OutPart), Tiles: fromTiles(res.Tiles), OutStates: FromStates(res.OutStates)}
+}
+
+// ToWorkerResponse returns the WorkerResponse held by a message
+func ToWorkerResponse(res *WorkerResponse) stubs.WorkerResponse {
+	return stubs.WorkerResponse{OutPart: ToBits(res.GetOutPart()), Tiles: toTiles(res.GetTiles()),
+		OutStates: ToStates(res.GetOutStates())}
+}
@@ -0,0 +1,2232 @@
+// The controller to broker and broker to worker protocols, the same calls as the net/rpc methods named in stubs,
+// for tooling that cannot speak gob. Fields follow the structs in stubs, and every call there has a call here of
+// the same name. WatchTurns and StreamStrips are streaming calls that net/rpc cannot make.
+//
+// The broker and workers serve these alongside net/rpc on the address given with -grpc. The controller calls the
+// broker over gRPC when given -grpc, and the broker calls its workers over gRPC when given -grpcworkers.
+// gameoflife.pb.go and gameoflife_grpc.pb.go are generated from this file by protoc-gen-go and protoc-gen-go-grpc,
+// run from this directory with
+//   protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative gameoflife.proto
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        (unknown)
+// source: gameoflife.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Boundary is how the edges of the world are joined, as util.Boundary
+type Boundary int32
+
+const (
+	Boundary_TORUS   Boundary = 0
+	Boundary_DEAD    Boundary = 1
+	Boundary_REFLECT Boundary = 2
+	Boundary_KLEIN   Boundary = 3
+	Boundary_CROSS   Boundary = 4
+)
+
+// Enum value maps for Boundary.
+var (
+	Boundary_name = map[int32]string{
+		0: "TORUS",
+		1: "DEAD",
+		2: "REFLECT",
+		3: "KLEIN",
+		4: "CROSS",
+	}
+	Boundary_value = map[string]int32{
+		"TORUS":   0,
+		"DEAD":    1,
+		"REFLECT": 2,
+		"KLEIN":   3,
+		"CROSS":   4,
+	}
+)
+
+func (x Boundary) Enum() *Boundary {
+	p := new(Boundary)
+	*p = x
+	return p
+}
+
+func (x Boundary) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Boundary) Descriptor() protoreflect.EnumDescriptor {
+	return file_gameoflife_proto_enumTypes[0].Descriptor()
+}
+
+func (Boundary) Type() protoreflect.EnumType {
+	return &file_gameoflife_proto_enumTypes[0]
+}
+
+func (x Boundary) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Boundary.Descriptor instead.
+func (Boundary) EnumDescriptor() ([]byte, []int) {
+	return file_gameoflife_proto_rawDescGZIP(), []int{0}
+}
+
+// BitRows are rows of a Life world, one bit per cell with the leftmost cell in the lowest bit of the first byte,
+// as util.BitArray
+type BitRows struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rows [][]byte `protobuf:"bytes,1,rep,name=rows,proto3" json:"rows,omitempty"`
+}
+
+func (x *BitRows) Reset() {
+	*x = BitRows{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gameoflife_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BitRows) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BitRows) ProtoMessage() {}
+
+func (x *BitRows) ProtoReflect() protoreflect.Message {
+	mi := &file_gameoflife_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BitRows.ProtoReflect.Descriptor instead.
+func (*BitRows) Descriptor() ([]byte, []int) {
+	return file_gameoflife_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *BitRows) GetRows() [][]byte {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+// StateRows are rows of a world of a rule with more than two states, packed bits cells to a byte with the leftmost
+// cell in the lowest bits, as util.StateArray
+type StateRows struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Bits uint32   `protobuf:"varint,1,opt,name=bits,proto3" json:"bits,omitempty"`
+	Rows [][]byte `protobuf:"bytes,2,rep,name=rows,proto3" json:"rows,omitempty"`
+}
+
+func (x *StateRows) Reset() {
+	*x = StateRows{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gameoflife_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StateRows) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StateRows) ProtoMessage() {}
+
+func (x *StateRows) ProtoReflect() protoreflect.Message {
+	mi := &file_gameoflife_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StateRows.ProtoReflect.Descriptor instead.
+func (*StateRows) Descriptor() ([]byte, []int) {
+	return file_gameoflife_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *StateRows) GetBits() uint32 {
+	if x != nil {
+		return x.Bits
+	}
+	return 0
+}
+
+func (x *StateRows) GetRows() [][]byte {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+// World is a Life world in cells, or the states of any other rule in states
+type World struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to World:
+	//	*World_Cells
+	//	*World_States
+	World isWorld_World `protobuf_oneof:"world"`
+}
+
+func (x *World) Reset() {
+	*x = World{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gameoflife_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *World) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*World) ProtoMessage() {}
+
+func (x *World) ProtoReflect() protoreflect.Message {
+	mi := &file_gameoflife_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use World.ProtoReflect.Descriptor instead.
+func (*World) Descriptor() ([]byte, []int) {
+	return file_gameoflife_proto_rawDescGZIP(), []int{2}
+}
+
+func (m *World) GetWorld() isWorld_World {
+	if m != nil {
+		return m.World
+	}
+	return nil
+}
+
+func (x *World) GetCells() *BitRows {
+	if x, ok := x.GetWorld().(*World_Cells); ok {
+		return x.Cells
+	}
+	return nil
+}
+
+func (x *World) GetStates() *StateRows {
+	if x, ok := x.GetWorld().(*World_States); ok {
+		return x.States
+	}
+	return nil
+}
+
+type isWorld_World interface {
+	isWorld_World()
+}
+
+type World_Cells struct {
+	Cells *BitRows `protobuf:"bytes,1,opt,name=cells,proto3,oneof"`
+}
+
+type World_States struct {
+	States *StateRows `protobuf:"bytes,2,opt,name=states,proto3,oneof"`
+}
+
+func (*World_Cells) isWorld_World() {}
+
+func (*World_States) isWorld_World() {}
+
+type Empty struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *Empty) Reset() {
+	*x = Empty{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gameoflife_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Empty) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Empty) ProtoMessage() {}
+
+func (x *Empty) ProtoReflect() protoreflect.Message {
+	mi := &file_gameoflife_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Empty.ProtoReflect.Descriptor instead.
+func (*Empty) Descriptor() ([]byte, []int) {
+	return file_gameoflife_proto_rawDescGZIP(), []int{3}
+}
+
+// Hello is sent by the caller at the start of a connection and answered with the other end's own, so that each can
+// tell whether it can talk to the other and what it may ask for. Peers without it are version 1
+type Hello struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version    int32    `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	MinVersion int32    `protobuf:"varint,2,opt,name=min_version,json=minVersion,proto3" json:"min_version,omitempty"`
+	Rules      []string `protobuf:"bytes,3,rep,name=rules,proto3" json:"rules,omitempty"`         // families of rule: life, generations, isotropic, ltl and fixed
+	Encodings  []string `protobuf:"bytes,4,rep,name=encodings,proto3" json:"encodings,omitempty"` // bits and states
+	Features   []string `protobuf:"bytes,5,rep,name=features,proto3" json:"features,omitempty"`   // as the Feature constants in stubs
+}
+
+func (x *Hello) Reset() {
+	*x = Hello{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gameoflife_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Hello) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Hello) ProtoMessage() {}
+
+func (x *Hello) ProtoReflect() protoreflect.Message {
+	mi := &file_gameoflife_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Hello.ProtoReflect.Descriptor instead.
+func (*Hello) Descriptor() ([]byte, []int) {
+	return file_gameoflife_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Hello) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Hello) GetMinVersion() int32 {
+	if x != nil {
+		return x.MinVersion
+	}
+	return 0
+}
+
+func (x *Hello) GetRules() []string {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+func (x *Hello) GetEncodings() []string {
+	if x != nil {
+		return x.Encodings
+	}
+	return nil
+}
+
+func (x *Hello) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+// Request starts a run of turns, resuming the current world instead of world when resume is set
+type Request struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Turns       int64    `protobuf:"varint,1,opt,name=turns,proto3" json:"turns,omitempty"`
+	ImageWidth  int32    `protobuf:"varint,2,opt,name=image_width,json=imageWidth,proto3" json:"image_width,omitempty"`
+	ImageHeight int32    `protobuf:"varint,3,opt,name=image_height,json=imageHeight,proto3" json:"image_height,omitempty"`
+	World       *World   `protobuf:"bytes,4,opt,name=world,proto3" json:"world,omitempty"`
+	Resume      bool     `protobuf:"varint,5,opt,name=resume,proto3" json:"resume,omitempty"`
+	Engine      string   `protobuf:"bytes,6,opt,name=engine,proto3" json:"engine,omitempty"` // step or hashlife
+	Boundary    Boundary `protobuf:"varint,7,opt,name=boundary,proto3,enum=gameoflife.Boundary" json:"boundary,omitempty"`
+	Rule        string   `protobuf:"bytes,8,opt,name=rule,proto3" json:"rule,omitempty"`
+	Threads     int32    `protobuf:"varint,9,opt,name=threads,proto3" json:"threads,omitempty"` // goroutines for each worker, 0 leaves it to the workers
+}
+
+func (x *Request) Reset() {
+	*x = Request{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gameoflife_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Request) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Request) ProtoMessage() {}
+
+func (x *Request) ProtoReflect() protoreflect.Message {
+	mi := &file_gameoflife_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Request.ProtoReflect.Descriptor instead.
+func (*Request) Descriptor() ([]byte, []int) {
+	return file_gameoflife_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Request) GetTurns() int64 {
+	if x != nil {
+		return x.Turns
+	}
+	return 0
+}
+
+func (x *Request) GetImageWidth() int32 {
+	if x != nil {
+		return x.ImageWidth
+	}
+	return 0
+}
+
+func (x *Request) GetImageHeight() int32 {
+	if x != nil {
+		return x.ImageHeight
+	}
+	return 0
+}
+
+func (x *Request) GetWorld() *World {
+	if x != nil {
+		return x.World
+	}
+	return nil
+}
+
+func (x *Request) GetResume() bool {
+	if x != nil {
+		return x.Resume
+	}
+	return false
+}
+
+func (x *Request) GetEngine() string {
+	if x != nil {
+		return x.Engine
+	}
+	return ""
+}
+
+func (x *Request) GetBoundary() Boundary {
+	if x != nil {
+		return x.Boundary
+	}
+	return Boundary_TORUS
+}
+
+func (x *Request) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+func (x *Request) GetThreads() int32 {
+	if x != nil {
+		return x.Threads
+	}
+	return 0
+}
+
+type Response struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NextWorld      *World `protobuf:"bytes,1,opt,name=next_world,json=nextWorld,proto3" json:"next_world,omitempty"`
+	CompletedTurns int64  `protobuf:"varint,2,opt,name=completed_turns,json=completedTurns,proto3" json:"completed_turns,omitempty"`
+}
+
+func (x *Response) Reset() {
+	*x = Response{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gameoflife_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Response) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Response) ProtoMessage() {}
+
+func (x *Response) ProtoReflect() protoreflect.Message {
+	mi := &file_gameoflife_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Response.ProtoReflect.Descriptor instead.
+func (*Response) Descriptor() ([]byte, []int) {
+	return file_gameoflife_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Response) GetNextWorld() *World {
+	if x != nil {
+		return x.NextWorld
+	}
+	return nil
+}
+
+func (x *Response) GetCompletedTurns() int64 {
+	if x != nil {
+		return x.CompletedTurns
+	}
+	return 0
+}
+
+type AliveCountAtRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Turn int64 `protobuf:"varint,1,opt,name=turn,proto3" json:"turn,omitempty"`
+}
+
+func (x *AliveCountAtRequest) Reset() {
+	*x = AliveCountAtRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gameoflife_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AliveCountAtRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AliveCountAtRequest) ProtoMessage() {}
+
+func (x *AliveCountAtRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gameoflife_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AliveCountAtRequest.ProtoReflect.Descriptor instead.
+func (*AliveCountAtRequest) Descriptor() ([]byte, []int) {
+	return file_gameoflife_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *AliveCountAtRequest) GetTurn() int64 {
+	if x != nil {
+		return x.Turn
+	}
+	return 0
+}
+
+// RegionRequest sets the cells of the width by height rectangle with its top left at x, y to state, or for
+// GetRegion reads them
+type RegionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	X      int32  `protobuf:"varint,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y      int32  `protobuf:"varint,2,opt,name=y,proto3" json:"y,omitempty"`
+	Width  int32  `protobuf:"varint,3,opt,name=width,proto3" json:"width,omitempty"`
+	Height int32  `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
+	State  uint32 `protobuf:"varint,5,opt,name=state,proto3" json:"state,omitempty"`
+}
+
+func (x *RegionRequest) Reset() {
+	*x = RegionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gameoflife_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RegionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegionRequest) ProtoMessage() {}
+
+func (x *RegionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gameoflife_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegionRequest.ProtoReflect.Descriptor instead.
+func (*RegionRequest) Descriptor() ([]byte, []int) {
+	return file_gameoflife_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RegionRequest) GetX() int32 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *RegionRequest) GetY() int32 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *RegionRequest) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *RegionRequest) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *RegionRequest) GetState() uint32 {
+	if x != nil {
+		return x.State
+	}
+	return 0
+}
+
+// StampRequest writes a pattern in RLE over the world with the top left of its bounding box at x, y
+type StampRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	X   int32  `protobuf:"varint,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y   int32  `protobuf:"varint,2,opt,name=y,proto3" json:"y,omitempty"`
+	Rle string `protobuf:"bytes,3,opt,name=rle,proto3" json:"rle,omitempty"`
+}
+
+func (x *StampRequest) Reset() {
+	*x = StampRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gameoflife_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StampRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StampRequest) ProtoMessage() {}
+
+func (x *StampRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gameoflife_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StampRequest.ProtoReflect.Descriptor instead.
+func (*StampRequest) Descriptor() ([]byte, []int) {
+	return file_gameoflife_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *StampRequest) GetX() int32 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *StampRequest) GetY() int32 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *StampRequest) GetRle() string {
+	if x != nil {
+		return x.Rle
+	}
+	return ""
+}
+
+// RegionResponse holds the cells of a rectangle that are not dead, with rows starting at column x, a multiple of 8
+type RegionResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	X              int32    `protobuf:"varint,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y              int32    `protobuf:"varint,2,opt,name=y,proto3" json:"y,omitempty"`
+	Rows           *BitRows `protobuf:"bytes,3,opt,name=rows,proto3" json:"rows,omitempty"`
+	CompletedTurns int64    `protobuf:"varint,4,opt,name=completed_turns,json=completedTurns,proto3" json:"completed_turns,omitempty"`
+}
+
+func (x *RegionResponse) Reset() {
+	*x = RegionResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gameoflife_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RegionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegionResponse) ProtoMessage() {}
+
+func (x *RegionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gameoflife_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegionResponse.ProtoReflect.Descriptor instead.
+func (*RegionResponse) Descriptor() ([]byte, []int) {
+	return file_gameoflife_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RegionResponse) GetX() int32 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *RegionResponse) GetY() int32 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *RegionResponse) GetRows() *BitRows {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+func (x *RegionResponse) GetCompletedTurns() int64 {
+	if x != nil {
+		return x.CompletedTurns
+	}
+	return 0
+}
+
+type AliveCellsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AliveCellsCount int64 `protobuf:"varint,1,opt,name=alive_cells_count,json=aliveCellsCount,proto3" json:"alive_cells_count,omitempty"`
+	CompletedTurns  int64 `protobuf:"varint,2,opt,name=completed_turns,json=completedTurns,proto3" json:"completed_turns,omitempty"`
+}
+
+func (x *AliveCellsResponse) Reset() {
+	*x = AliveCellsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gameoflife_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AliveCellsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AliveCellsResponse) ProtoMessage() {}
+
+func (x *AliveCellsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gameoflife_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AliveCellsResponse.ProtoReflect.Descriptor instead.
+func (*AliveCellsResponse) Descriptor() ([]byte, []int) {
+	return file_gameoflife_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *AliveCellsResponse) GetAliveCellsCount() int64 {
+	if x != nil {
+		return x.AliveCellsCount
+	}
+	return 0
+}
+
+func (x *AliveCellsResponse) GetCompletedTurns() int64 {
+	if x != nil {
+		return x.CompletedTurns
+	}
+	return 0
+}
+
+type CurrentWorldResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	World          *World `protobuf:"bytes,1,opt,name=world,proto3" json:"world,omitempty"`
+	CompletedTurns int64  `protobuf:"varint,2,opt,name=completed_turns,json=completedTurns,proto3" json:"completed_turns,omitempty"`
+}
+
+func (x *CurrentWorldResponse) Reset() {
+	*x = CurrentWorldResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gameoflife_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CurrentWorldResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CurrentWorldResponse) ProtoMessage() {}
+
+func (x *CurrentWorldResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gameoflife_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CurrentWorldResponse.ProtoReflect.Descriptor instead.
+func (*CurrentWorldResponse) Descriptor() ([]byte, []int) {
+	return file_gameoflife_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CurrentWorldResponse) GetWorld() *World {
+	if x != nil {
+		return x.World
+	}
+	return nil
+}
+
+func (x *CurrentWorldResponse) GetCompletedTurns() int64 {
+	if x != nil {
+		return x.CompletedTurns
+	}
+	return 0
+}
+
+type PauseServerResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CompletedTurns int64 `protobuf:"varint,1,opt,name=completed_turns,json=completedTurns,proto3" json:"completed_turns,omitempty"`
+}
+
+func (x *PauseServerResponse) Reset() {
+	*x = PauseServerResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gameoflife_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PauseServerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseServerResponse) ProtoMessage() {}
+
+func (x *PauseServerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gameoflife_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseServerResponse.ProtoReflect.Descriptor instead.
+func (*PauseServerResponse) Descriptor() ([]byte, []int) {
+	return file_gameoflife_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *PauseServerResponse) GetCompletedTurns() int64 {
+	if x != nil {
+		return x.CompletedTurns
+	}
+	return 0
+}
+
+type WorkerStatsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Split          []int32   `protobuf:"varint,1,rep,packed,name=split,proto3" json:"split,omitempty"`
+	NsPerRow       []float64 `protobuf:"fixed64,2,rep,packed,name=ns_per_row,json=nsPerRow,proto3" json:"ns_per_row,omitempty"`
+	CompletedTurns int64     `protobuf:"varint,3,opt,name=completed_turns,json=completedTurns,proto3" json:"completed_turns,omitempty"`
+	Workers        int32     `protobuf:"varint,4,opt,name=workers,proto3" json:"workers,omitempty"`
+}
+
+func (x *WorkerStatsResponse) Reset() {
+	*x = WorkerStatsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gameoflife_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WorkerStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkerStatsResponse) ProtoMessage() {}
+
+func (x *WorkerStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gameoflife_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkerStatsResponse.ProtoReflect.Descriptor instead.
+func (*WorkerStatsResponse) Descriptor() ([]byte, []int) {
+	return file_gameoflife_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *WorkerStatsResponse) GetSplit() []int32 {
+	if x != nil {
+		return x.Split
+	}
+	return nil
+}
+
+func (x *WorkerStatsResponse) GetNsPerRow() []float64 {
+	if x != nil {
+		return x.NsPerRow
+	}
+	return nil
+}
+
+func (x *WorkerStatsResponse) GetCompletedTurns() int64 {
+	if x != nil {
+		return x.CompletedTurns
+	}
+	return 0
+}
+
+func (x *WorkerStatsResponse) GetWorkers() int32 {
+	if x != nil {
+		return x.Workers
+	}
+	return 0
+}
+
+// WatchRequest limits the diffs to a rectangle of the world, or the whole world when width and height are 0
+type WatchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	X      int32 `protobuf:"varint,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y      int32 `protobuf:"varint,2,opt,name=y,proto3" json:"y,omitempty"`
+	Width  int32 `protobuf:"varint,3,opt,name=width,proto3" json:"width,omitempty"`
+	Height int32 `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
+}
+
+func (x *WatchRequest) Reset() {
+	*x = WatchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gameoflife_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchRequest) ProtoMessage() {}
+
+func (x *WatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gameoflife_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchRequest.ProtoReflect.Descriptor instead.
+func (*WatchRequest) Descriptor() ([]byte, []int) {
+	return file_gameoflife_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *WatchRequest) GetX() int32 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *WatchRequest) GetY() int32 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *WatchRequest) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *WatchRequest) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+type Cell struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	X int32 `protobuf:"varint,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y int32 `protobuf:"varint,2,opt,name=y,proto3" json:"y,omitempty"`
+}
+
+func (x *Cell) Reset() {
+	*x = Cell{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gameoflife_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Cell) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Cell) ProtoMessage() {}
+
+func (x *Cell) ProtoReflect() protoreflect.Message {
+	mi := &file_gameoflife_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Cell.ProtoReflect.Descriptor instead.
+func (*Cell) Descriptor() ([]byte, []int) {
+	return file_gameoflife_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Cell) GetX() int32 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *Cell) GetY() int32 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+// TurnDiff holds the cells that flipped on a turn, with their new states for rules with more than two states
+type TurnDiff struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CompletedTurns int64   `protobuf:"varint,1,opt,name=completed_turns,json=completedTurns,proto3" json:"completed_turns,omitempty"`
+	Flipped        []*Cell `protobuf:"bytes,2,rep,name=flipped,proto3" json:"flipped,omitempty"`
+	States         []byte  `protobuf:"bytes,3,opt,name=states,proto3" json:"states,omitempty"`
+}
+
+func (x *TurnDiff) Reset() {
+	*x = TurnDiff{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gameoflife_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TurnDiff) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TurnDiff) ProtoMessage() {}
+
+func (x *TurnDiff) ProtoReflect() protoreflect.Message {
+	mi := &file_gameoflife_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TurnDiff.ProtoReflect.Descriptor instead.
+func (*TurnDiff) Descriptor() ([]byte, []int) {
+	return file_gameoflife_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *TurnDiff) GetCompletedTurns() int64 {
+	if x != nil {
+		return x.CompletedTurns
+	}
+	return 0
+}
+
+func (x *TurnDiff) GetFlipped() []*Cell {
+	if x != nil {
+		return x.Flipped
+	}
+	return nil
+}
+
+func (x *TurnDiff) GetStates() []byte {
+	if x != nil {
+		return x.States
+	}
+	return nil
+}
+
+type TileIndex struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	X int32 `protobuf:"varint,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y int32 `protobuf:"varint,2,opt,name=y,proto3" json:"y,omitempty"`
+}
+
+func (x *TileIndex) Reset() {
+	*x = TileIndex{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gameoflife_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TileIndex) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TileIndex) ProtoMessage() {}
+
+func (x *TileIndex) ProtoReflect() protoreflect.Message {
+	mi := &file_gameoflife_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TileIndex.ProtoReflect.Descriptor instead.
+func (*TileIndex) Descriptor() ([]byte, []int) {
+	return file_gameoflife_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *TileIndex) GetX() int32 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *TileIndex) GetY() int32 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+// Tile is a rectangle of the world with its top left cell at x, y
+type Tile struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	X    int32    `protobuf:"varint,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y    int32    `protobuf:"varint,2,opt,name=y,proto3" json:"y,omitempty"`
+	Rows *BitRows `protobuf:"bytes,3,opt,name=rows,proto3" json:"rows,omitempty"`
+}
+
+func (x *Tile) Reset() {
+	*x = Tile{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gameoflife_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Tile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tile) ProtoMessage() {}
+
+func (x *Tile) ProtoReflect() protoreflect.Message {
+	mi := &file_gameoflife_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tile.ProtoReflect.Descriptor instead.
+func (*Tile) Descriptor() ([]byte, []int) {
+	return file_gameoflife_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Tile) GetX() int32 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *Tile) GetY() int32 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *Tile) GetRows() *BitRows {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+// WorkerRequest holds a strip of the world with the extra rows above and below it that the rule reads, and for
+// boundaries that need them the cells beyond the left and right of each row. A sparse request holds halos instead,
+// each of active_tiles with a byte of cells beyond its sides and a row beyond its top and bottom
+type WorkerRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Scale       int32        `protobuf:"varint,1,opt,name=scale,proto3" json:"scale,omitempty"`
+	WorldWidth  int32        `protobuf:"varint,2,opt,name=world_width,json=worldWidth,proto3" json:"world_width,omitempty"`
+	InPart      *BitRows     `protobuf:"bytes,3,opt,name=in_part,json=inPart,proto3" json:"in_part,omitempty"`
+	Sparse      bool         `protobuf:"varint,4,opt,name=sparse,proto3" json:"sparse,omitempty"`
+	StartY      int32        `protobuf:"varint,5,opt,name=start_y,json=startY,proto3" json:"start_y,omitempty"`
+	ActiveTiles []*TileIndex `protobuf:"bytes,6,rep,name=active_tiles,json=activeTiles,proto3" json:"active_tiles,omitempty"`
+	Boundary    Boundary     `protobuf:"varint,7,opt,name=boundary,proto3,enum=gameoflife.Boundary" json:"boundary,omitempty"`
+	West        []bool       `protobuf:"varint,8,rep,packed,name=west,proto3" json:"west,omitempty"`
+	East        []bool       `protobuf:"varint,9,rep,packed,name=east,proto3" json:"east,omitempty"`
+	Rule        string       `protobuf:"bytes,10,opt,name=rule,proto3" json:"rule,omitempty"`
+	InStates    *StateRows   `protobuf:"bytes,11,opt,name=in_states,json=inStates,proto3" json:"in_states,omitempty"`
+	WestStates  [][]byte     `protobuf:"bytes,12,rep,name=west_states,json=westStates,proto3" json:"west_states,omitempty"`
+	EastStates  [][]byte     `protobuf:"bytes,13,rep,name=east_states,json=eastStates,proto3" json:"east_states,omitempty"`
+	Threads     int32        `protobuf:"varint,14,opt,name=threads,proto3" json:"threads,omitempty"`
+	Halos       []*Tile      `protobuf:"bytes,15,rep,name=halos,proto3" json:"halos,omitempty"`
+}
+
+func (x *WorkerRequest) Reset() {
+	*x = WorkerRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gameoflife_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WorkerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkerRequest) ProtoMessage() {}
+
+func (x *WorkerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gameoflife_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkerRequest.ProtoReflect.Descriptor instead.
+func (*WorkerRequest) Descriptor() ([]byte, []int) {
+	return file_gameoflife_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *WorkerRequest) GetScale() int32 {
+	if x != nil {
+		return x.Scale
+	}
+	return 0
+}
+
+func (x *WorkerRequest) GetWorldWidth() int32 {
+	if x != nil {
+		return x.WorldWidth
+	}
+	return 0
+}
+
+func (x *WorkerRequest) GetInPart() *BitRows {
+	if x != nil {
+		return x.InPart
+	}
+	return nil
+}
+
+func (x *WorkerRequest) GetSparse() bool {
+	if x != nil {
+		return x.Sparse
+	}
+	return false
+}
+
+func (x *WorkerRequest) GetStartY() int32 {
+	if x != nil {
+		return x.StartY
+	}
+	return 0
+}
+
+func (x *WorkerRequest) GetActiveTiles() []*TileIndex {
+	if x != nil {
+		return x.ActiveTiles
+	}
+	return nil
+}
+
+func (x *WorkerRequest) GetBoundary() Boundary {
+	if x != nil {
+		return x.Boundary
+	}
+	return Boundary_TORUS
+}
+
+func (x *WorkerRequest) GetWest() []bool {
+	if x != nil {
+		return x.West
+	}
+	return nil
+}
+
+func (x *WorkerRequest) GetEast() []bool {
+	if x != nil {
+		return x.East
+	}
+	return nil
+}
+
+func (x *WorkerRequest) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+func (x *WorkerRequest) GetInStates() *StateRows {
+	if x != nil {
+		return x.InStates
+	}
+	return nil
+}
+
+func (x *WorkerRequest) GetWestStates() [][]byte {
+	if x != nil {
+		return x.WestStates
+	}
+	return nil
+}
+
+func (x *WorkerRequest) GetEastStates() [][]byte {
+	if x != nil {
+		return x.EastStates
+	}
+	return nil
+}
+
+func (x *WorkerRequest) GetThreads() int32 {
+	if x != nil {
+		return x.Threads
+	}
+	return 0
+}
+
+func (x *WorkerRequest) GetHalos() []*Tile {
+	if x != nil {
+		return x.Halos
+	}
+	return nil
+}
+
+// WorkerResponse holds the next state of the strip, or only the tiles that changed when the request was sparse
+type WorkerResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OutPart   *BitRows   `protobuf:"bytes,1,opt,name=out_part,json=outPart,proto3" json:"out_part,omitempty"`
+	Tiles     []*Tile    `protobuf:"bytes,2,rep,name=tiles,proto3" json:"tiles,omitempty"`
+	OutStates *StateRows `protobuf:"bytes,3,opt,name=out_states,json=outStates,proto3" json:"out_states,omitempty"`
+}
+
+func (x *WorkerResponse) Reset() {
+	*x = WorkerResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gameoflife_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WorkerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkerResponse) ProtoMessage() {}
+
+func (x *WorkerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gameoflife_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkerResponse.ProtoReflect.Descriptor instead.
+func (*WorkerResponse) Descriptor() ([]byte, []int) {
+	return file_gameoflife_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *WorkerResponse) GetOutPart() *BitRows {
+	if x != nil {
+		return x.OutPart
+	}
+	return nil
+}
+
+func (x *WorkerResponse) GetTiles() []*Tile {
+	if x != nil {
+		return x.Tiles
+	}
+	return nil
+}
+
+func (x *WorkerResponse) GetOutStates() *StateRows {
+	if x != nil {
+		return x.OutStates
+	}
+	return nil
+}
+
+var File_gameoflife_proto protoreflect.FileDescriptor
+
+var file_gameoflife_proto_rawDesc = []byte{
+	0x0a, 0x10, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x0a, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x22, 0x1d,
+	0x0a, 0x07, 0x42, 0x69, 0x74, 0x52, 0x6f, 0x77, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f, 0x77,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x22, 0x33, 0x0a,
+	0x09, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x6f, 0x77, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x69,
+	0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x62, 0x69, 0x74, 0x73, 0x12, 0x12,
+	0x0a, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x04, 0x72, 0x6f,
+	0x77, 0x73, 0x22, 0x6e, 0x0a, 0x05, 0x57, 0x6f, 0x72, 0x6c, 0x64, 0x12, 0x2b, 0x0a, 0x05, 0x63,
+	0x65, 0x6c, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x67, 0x61, 0x6d,
+	0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x2e, 0x42, 0x69, 0x74, 0x52, 0x6f, 0x77, 0x73, 0x48,
+	0x00, 0x52, 0x05, 0x63, 0x65, 0x6c, 0x6c, 0x73, 0x12, 0x2f, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f,
+	0x66, 0x6c, 0x69, 0x66, 0x65, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x6f, 0x77, 0x73, 0x48,
+	0x00, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x65, 0x73, 0x42, 0x07, 0x0a, 0x05, 0x77, 0x6f, 0x72,
+	0x6c, 0x64, 0x22, 0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x92, 0x01, 0x0a, 0x05,
+	0x48, 0x65, 0x6c, 0x6c, 0x6f, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12,
+	0x1f, 0x0a, 0x0b, 0x6d, 0x69, 0x6e, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x6d, 0x69, 0x6e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x14, 0x0a, 0x05, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x05, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69,
+	0x6e, 0x67, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x65, 0x6e, 0x63, 0x6f, 0x64,
+	0x69, 0x6e, 0x67, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73,
+	0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73,
+	0x22, 0x9c, 0x02, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05,
+	0x74, 0x75, 0x72, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x74, 0x75, 0x72,
+	0x6e, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x77, 0x69, 0x64, 0x74,
+	0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x57, 0x69,
+	0x64, 0x74, 0x68, 0x12, 0x21, 0x0a, 0x0c, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x68, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x69, 0x6d, 0x61, 0x67, 0x65,
+	0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x27, 0x0a, 0x05, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69,
+	0x66, 0x65, 0x2e, 0x57, 0x6f, 0x72, 0x6c, 0x64, 0x52, 0x05, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x12,
+	0x16, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x06, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x6e, 0x67, 0x69, 0x6e,
+	0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x12,
+	0x30, 0x0a, 0x08, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x61, 0x72, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x14, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x2e, 0x42,
+	0x6f, 0x75, 0x6e, 0x64, 0x61, 0x72, 0x79, 0x52, 0x08, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x61, 0x72,
+	0x79, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x75, 0x6c, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x72, 0x75, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x22,
+	0x65, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x30, 0x0a, 0x0a, 0x6e,
+	0x65, 0x78, 0x74, 0x5f, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x11, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x2e, 0x57, 0x6f, 0x72,
+	0x6c, 0x64, 0x52, 0x09, 0x6e, 0x65, 0x78, 0x74, 0x57, 0x6f, 0x72, 0x6c, 0x64, 0x12, 0x27, 0x0a,
+	0x0f, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x5f, 0x74, 0x75, 0x72, 0x6e, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65,
+	0x64, 0x54, 0x75, 0x72, 0x6e, 0x73, 0x22, 0x29, 0x0a, 0x13, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x41, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a,
+	0x04, 0x74, 0x75, 0x72, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x75, 0x72,
+	0x6e, 0x22, 0x6f, 0x0a, 0x0d, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x0c, 0x0a, 0x01, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x78,
+	0x12, 0x0c, 0x0a, 0x01, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x77,
+	0x69, 0x64, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x14, 0x0a, 0x05,
+	0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x22, 0x3c, 0x0a, 0x0c, 0x53, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x0c, 0x0a, 0x01, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x78,
+	0x12, 0x0c, 0x0a, 0x01, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x72, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x72, 0x6c, 0x65,
+	0x22, 0x7e, 0x0a, 0x0e, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x0c, 0x0a, 0x01, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x78,
+	0x12, 0x0c, 0x0a, 0x01, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x79, 0x12, 0x27,
+	0x0a, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x67,
+	0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x2e, 0x42, 0x69, 0x74, 0x52, 0x6f, 0x77,
+	0x73, 0x52, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6f, 0x6d, 0x70, 0x6c,
+	0x65, 0x74, 0x65, 0x64, 0x5f, 0x74, 0x75, 0x72, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0e, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x54, 0x75, 0x72, 0x6e, 0x73,
+	0x22, 0x69, 0x0a, 0x12, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x43, 0x65, 0x6c, 0x6c, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2a, 0x0a, 0x11, 0x61, 0x6c, 0x69, 0x76, 0x65, 0x5f,
+	0x63, 0x65, 0x6c, 0x6c, 0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0f, 0x61, 0x6c, 0x69, 0x76, 0x65, 0x43, 0x65, 0x6c, 0x6c, 0x73, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x5f,
+	0x74, 0x75, 0x72, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x63, 0x6f, 0x6d,
+	0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x54, 0x75, 0x72, 0x6e, 0x73, 0x22, 0x68, 0x0a, 0x14, 0x43,
+	0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x57, 0x6f, 0x72, 0x6c, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x27, 0x0a, 0x05, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x11, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x2e,
+	0x57, 0x6f, 0x72, 0x6c, 0x64, 0x52, 0x05, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x12, 0x27, 0x0a, 0x0f,
+	0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x5f, 0x74, 0x75, 0x72, 0x6e, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64,
+	0x54, 0x75, 0x72, 0x6e, 0x73, 0x22, 0x3e, 0x0a, 0x13, 0x50, 0x61, 0x75, 0x73, 0x65, 0x53, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x27, 0x0a, 0x0f,
+	0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x5f, 0x74, 0x75, 0x72, 0x6e, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64,
+	0x54, 0x75, 0x72, 0x6e, 0x73, 0x22, 0x8c, 0x01, 0x0a, 0x13, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x73, 0x70, 0x6c, 0x69, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x05, 0x52, 0x05, 0x73, 0x70,
+	0x6c, 0x69, 0x74, 0x12, 0x1c, 0x0a, 0x0a, 0x6e, 0x73, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x72, 0x6f,
+	0x77, 0x18, 0x02, 0x20, 0x03, 0x28, 0x01, 0x52, 0x08, 0x6e, 0x73, 0x50, 0x65, 0x72, 0x52, 0x6f,
+	0x77, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x5f, 0x74,
+	0x75, 0x72, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x63, 0x6f, 0x6d, 0x70,
+	0x6c, 0x65, 0x74, 0x65, 0x64, 0x54, 0x75, 0x72, 0x6e, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x77, 0x6f,
+	0x72, 0x6b, 0x65, 0x72, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x77, 0x6f, 0x72,
+	0x6b, 0x65, 0x72, 0x73, 0x22, 0x58, 0x0a, 0x0c, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x0c, 0x0a, 0x01, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x01, 0x78, 0x12, 0x0c, 0x0a, 0x01, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x79,
+	0x12, 0x14, 0x0a, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22, 0x22,
+	0x0a, 0x04, 0x43, 0x65, 0x6c, 0x6c, 0x12, 0x0c, 0x0a, 0x01, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x01, 0x78, 0x12, 0x0c, 0x0a, 0x01, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x01, 0x79, 0x22, 0x77, 0x0a, 0x08, 0x54, 0x75, 0x72, 0x6e, 0x44, 0x69, 0x66, 0x66, 0x12, 0x27,
+	0x0a, 0x0f, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x5f, 0x74, 0x75, 0x72, 0x6e,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74,
+	0x65, 0x64, 0x54, 0x75, 0x72, 0x6e, 0x73, 0x12, 0x2a, 0x0a, 0x07, 0x66, 0x6c, 0x69, 0x70, 0x70,
+	0x65, 0x64, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f,
+	0x66, 0x6c, 0x69, 0x66, 0x65, 0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x52, 0x07, 0x66, 0x6c, 0x69, 0x70,
+	0x70, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x65, 0x73, 0x22, 0x27, 0x0a, 0x09, 0x54,
+	0x69, 0x6c, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x0c, 0x0a, 0x01, 0x78, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x01, 0x78, 0x12, 0x0c, 0x0a, 0x01, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x01, 0x79, 0x22, 0x4b, 0x0a, 0x04, 0x54, 0x69, 0x6c, 0x65, 0x12, 0x0c, 0x0a, 0x01,
+	0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x78, 0x12, 0x0c, 0x0a, 0x01, 0x79, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x79, 0x12, 0x27, 0x0a, 0x04, 0x72, 0x6f, 0x77, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c,
+	0x69, 0x66, 0x65, 0x2e, 0x42, 0x69, 0x74, 0x52, 0x6f, 0x77, 0x73, 0x52, 0x04, 0x72, 0x6f, 0x77,
+	0x73, 0x22, 0x85, 0x04, 0x0a, 0x0d, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x61, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x05, 0x73, 0x63, 0x61, 0x6c, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x77, 0x6f, 0x72,
+	0x6c, 0x64, 0x5f, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a,
+	0x77, 0x6f, 0x72, 0x6c, 0x64, 0x57, 0x69, 0x64, 0x74, 0x68, 0x12, 0x2c, 0x0a, 0x07, 0x69, 0x6e,
+	0x5f, 0x70, 0x61, 0x72, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x67, 0x61,
+	0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x2e, 0x42, 0x69, 0x74, 0x52, 0x6f, 0x77, 0x73,
+	0x52, 0x06, 0x69, 0x6e, 0x50, 0x61, 0x72, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x70, 0x61, 0x72,
+	0x73, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x70, 0x61, 0x72, 0x73, 0x65,
+	0x12, 0x17, 0x0a, 0x07, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x06, 0x73, 0x74, 0x61, 0x72, 0x74, 0x59, 0x12, 0x38, 0x0a, 0x0c, 0x61, 0x63, 0x74,
+	0x69, 0x76, 0x65, 0x5f, 0x74, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x15, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x2e, 0x54, 0x69, 0x6c,
+	0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x52, 0x0b, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x54, 0x69,
+	0x6c, 0x65, 0x73, 0x12, 0x30, 0x0a, 0x08, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x61, 0x72, 0x79, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69,
+	0x66, 0x65, 0x2e, 0x42, 0x6f, 0x75, 0x6e, 0x64, 0x61, 0x72, 0x79, 0x52, 0x08, 0x62, 0x6f, 0x75,
+	0x6e, 0x64, 0x61, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x77, 0x65, 0x73, 0x74, 0x18, 0x08, 0x20,
+	0x03, 0x28, 0x08, 0x52, 0x04, 0x77, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x65, 0x61, 0x73,
+	0x74, 0x18, 0x09, 0x20, 0x03, 0x28, 0x08, 0x52, 0x04, 0x65, 0x61, 0x73, 0x74, 0x12, 0x12, 0x0a,
+	0x04, 0x72, 0x75, 0x6c, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x75, 0x6c,
+	0x65, 0x12, 0x32, 0x0a, 0x09, 0x69, 0x6e, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x73, 0x18, 0x0b,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66,
+	0x65, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x6f, 0x77, 0x73, 0x52, 0x08, 0x69, 0x6e, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x77, 0x65, 0x73, 0x74, 0x5f, 0x73, 0x74,
+	0x61, 0x74, 0x65, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0a, 0x77, 0x65, 0x73, 0x74,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x65, 0x61, 0x73, 0x74, 0x5f, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0a, 0x65, 0x61, 0x73,
+	0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x68, 0x72, 0x65, 0x61,
+	0x64, 0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64,
+	0x73, 0x12, 0x26, 0x0a, 0x05, 0x68, 0x61, 0x6c, 0x6f, 0x73, 0x18, 0x0f, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x10, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x2e, 0x54, 0x69,
+	0x6c, 0x65, 0x52, 0x05, 0x68, 0x61, 0x6c, 0x6f, 0x73, 0x22, 0x9e, 0x01, 0x0a, 0x0e, 0x57, 0x6f,
+	0x72, 0x6b, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x08,
+	0x6f, 0x75, 0x74, 0x5f, 0x70, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13,
+	0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x2e, 0x42, 0x69, 0x74, 0x52,
+	0x6f, 0x77, 0x73, 0x52, 0x07, 0x6f, 0x75, 0x74, 0x50, 0x61, 0x72, 0x74, 0x12, 0x26, 0x0a, 0x05,
+	0x74, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x67, 0x61,
+	0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x2e, 0x54, 0x69, 0x6c, 0x65, 0x52, 0x05, 0x74,
+	0x69, 0x6c, 0x65, 0x73, 0x12, 0x34, 0x0a, 0x0a, 0x6f, 0x75, 0x74, 0x5f, 0x73, 0x74, 0x61, 0x74,
+	0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f,
+	0x66, 0x6c, 0x69, 0x66, 0x65, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x6f, 0x77, 0x73, 0x52,
+	0x09, 0x6f, 0x75, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x73, 0x2a, 0x42, 0x0a, 0x08, 0x42, 0x6f,
+	0x75, 0x6e, 0x64, 0x61, 0x72, 0x79, 0x12, 0x09, 0x0a, 0x05, 0x54, 0x4f, 0x52, 0x55, 0x53, 0x10,
+	0x00, 0x12, 0x08, 0x0a, 0x04, 0x44, 0x45, 0x41, 0x44, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x52,
+	0x45, 0x46, 0x4c, 0x45, 0x43, 0x54, 0x10, 0x02, 0x12, 0x09, 0x0a, 0x05, 0x4b, 0x4c, 0x45, 0x49,
+	0x4e, 0x10, 0x03, 0x12, 0x09, 0x0a, 0x05, 0x43, 0x52, 0x4f, 0x53, 0x53, 0x10, 0x04, 0x32, 0x9b,
+	0x07, 0x0a, 0x06, 0x42, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x12, 0x2d, 0x0a, 0x05, 0x48, 0x65, 0x6c,
+	0x6c, 0x6f, 0x12, 0x11, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x2e,
+	0x48, 0x65, 0x6c, 0x6c, 0x6f, 0x1a, 0x11, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69,
+	0x66, 0x65, 0x2e, 0x48, 0x65, 0x6c, 0x6c, 0x6f, 0x12, 0x3a, 0x0a, 0x0d, 0x52, 0x75, 0x6e, 0x47,
+	0x61, 0x6d, 0x65, 0x4f, 0x66, 0x4c, 0x69, 0x66, 0x65, 0x12, 0x13, 0x2e, 0x67, 0x61, 0x6d, 0x65,
+	0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14,
+	0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x42, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x69, 0x76, 0x65,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x11, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69,
+	0x66, 0x65, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1e, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f,
+	0x66, 0x6c, 0x69, 0x66, 0x65, 0x2e, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x43, 0x65, 0x6c, 0x6c, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x46, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x43,
+	0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x57, 0x6f, 0x72, 0x6c, 0x64, 0x12, 0x11, 0x2e, 0x67, 0x61,
+	0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x20,
+	0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x2e, 0x43, 0x75, 0x72, 0x72,
+	0x65, 0x6e, 0x74, 0x57, 0x6f, 0x72, 0x6c, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x31, 0x0a, 0x09, 0x48, 0x61, 0x6c, 0x74, 0x54, 0x75, 0x72, 0x6e, 0x73, 0x12, 0x11, 0x2e,
+	0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x1a, 0x11, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x12, 0x41, 0x0a, 0x0b, 0x50, 0x61, 0x75, 0x73, 0x65, 0x53, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x12, 0x11, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1f, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69,
+	0x66, 0x65, 0x2e, 0x50, 0x61, 0x75, 0x73, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x0b, 0x4b, 0x69, 0x6c, 0x6c, 0x43, 0x6c,
+	0x69, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x11, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69,
+	0x66, 0x65, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x11, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f,
+	0x66, 0x6c, 0x69, 0x66, 0x65, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x44, 0x0a, 0x0e, 0x47,
+	0x65, 0x74, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x11, 0x2e,
+	0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x1a, 0x1f, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x2e, 0x57, 0x6f,
+	0x72, 0x6b, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x52, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x41, 0x74, 0x12, 0x1f, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66,
+	0x65, 0x2e, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x41, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69,
+	0x66, 0x65, 0x2e, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x43, 0x65, 0x6c, 0x6c, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x46, 0x0a, 0x09, 0x53, 0x65, 0x74, 0x52, 0x65, 0x67, 0x69,
+	0x6f, 0x6e, 0x12, 0x19, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x2e,
+	0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e,
+	0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x2e, 0x41, 0x6c, 0x69, 0x76, 0x65,
+	0x43, 0x65, 0x6c, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x48, 0x0a,
+	0x0c, 0x53, 0x74, 0x61, 0x6d, 0x70, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x12, 0x18, 0x2e,
+	0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x2e, 0x53, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66,
+	0x6c, 0x69, 0x66, 0x65, 0x2e, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x43, 0x65, 0x6c, 0x6c, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3f, 0x0a, 0x0a, 0x43, 0x6c, 0x65, 0x61, 0x72,
+	0x57, 0x6f, 0x72, 0x6c, 0x64, 0x12, 0x11, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69,
+	0x66, 0x65, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1e, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f,
+	0x66, 0x6c, 0x69, 0x66, 0x65, 0x2e, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x43, 0x65, 0x6c, 0x6c, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x42, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x52,
+	0x65, 0x67, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69,
+	0x66, 0x65, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1a, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x2e, 0x52, 0x65,
+	0x67, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3e, 0x0a, 0x0a,
+	0x57, 0x61, 0x74, 0x63, 0x68, 0x54, 0x75, 0x72, 0x6e, 0x73, 0x12, 0x18, 0x2e, 0x67, 0x61, 0x6d,
+	0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66,
+	0x65, 0x2e, 0x54, 0x75, 0x72, 0x6e, 0x44, 0x69, 0x66, 0x66, 0x30, 0x01, 0x32, 0xf7, 0x01, 0x0a,
+	0x06, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x12, 0x2d, 0x0a, 0x05, 0x48, 0x65, 0x6c, 0x6c, 0x6f,
+	0x12, 0x11, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x2e, 0x48, 0x65,
+	0x6c, 0x6c, 0x6f, 0x1a, 0x11, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65,
+	0x2e, 0x48, 0x65, 0x6c, 0x6c, 0x6f, 0x12, 0x3f, 0x0a, 0x06, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72,
+	0x12, 0x19, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x2e, 0x57, 0x6f,
+	0x72, 0x6b, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x67, 0x61,
+	0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x32, 0x0a, 0x0a, 0x4b, 0x69, 0x6c, 0x6c, 0x57,
+	0x6f, 0x72, 0x6b, 0x65, 0x72, 0x12, 0x11, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69,
+	0x66, 0x65, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x11, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f,
+	0x66, 0x6c, 0x69, 0x66, 0x65, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x49, 0x0a, 0x0c, 0x53,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x53, 0x74, 0x72, 0x69, 0x70, 0x73, 0x12, 0x19, 0x2e, 0x67, 0x61,
+	0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69, 0x66, 0x65, 0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c,
+	0x69, 0x66, 0x65, 0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x28, 0x01, 0x30, 0x01, 0x42, 0x26, 0x5a, 0x24, 0x75, 0x6b, 0x2e, 0x61, 0x63, 0x2e,
+	0x62, 0x72, 0x69, 0x73, 0x2e, 0x63, 0x73, 0x2f, 0x67, 0x61, 0x6d, 0x65, 0x6f, 0x66, 0x6c, 0x69,
+	0x66, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x3b, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_gameoflife_proto_rawDescOnce sync.Once
+	file_gameoflife_proto_rawDescData = file_gameoflife_proto_rawDesc
+)
+
+func file_gameoflife_proto_rawDescGZIP() []byte {
+	file_gameoflife_proto_rawDescOnce.Do(func() {
+		file_gameoflife_proto_rawDescData = protoimpl.X.CompressGZIP(file_gameoflife_proto_rawDescData)
+	})
+	return file_gameoflife_proto_rawDescData
+}
+
+var file_gameoflife_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_gameoflife_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
+var file_gameoflife_proto_goTypes = []interface{}{
+	(Boundary)(0),                // 0: gameoflife.Boundary
+	(*BitRows)(nil),              // 1: gameoflife.BitRows
+	(*StateRows)(nil),            // 2: gameoflife.StateRows
+	(*World)(nil),                // 3: gameoflife.World
+	(*Empty)(nil),                // 4: gameoflife.Empty
+	(*Hello)(nil),                // 5: gameoflife.Hello
+	(*Request)(nil),              // 6: gameoflife.Request
+	(*Response)(nil),             // 7: gameoflife.Response
+	(*AliveCountAtRequest)(nil),  // 8: gameoflife.AliveCountAtRequest
+	(*RegionRequest)(nil),        // 9: gameoflife.RegionRequest
+	(*StampRequest)(nil),         // 10: gameoflife.StampRequest
+	(*RegionResponse)(nil),       // 11: gameoflife.RegionResponse
+	(*AliveCellsResponse)(nil),   // 12: gameoflife.AliveCellsResponse
+	(*CurrentWorldResponse)(nil), // 13: gameoflife.CurrentWorldResponse
+	(*PauseServerResponse)(nil),  // 14: gameoflife.PauseServerResponse
+	(*WorkerStatsResponse)(nil),  // 15: gameoflife.WorkerStatsResponse
+	(*WatchRequest)(nil),         // 16: gameoflife.WatchRequest
+	(*Cell)(nil),                 // 17: gameoflife.Cell
+	(*TurnDiff)(nil),             // 18: gameoflife.TurnDiff
+	(*TileIndex)(nil),            // 19: gameoflife.TileIndex
+	(*Tile)(nil),                 // 20: gameoflife.Tile
+	(*WorkerRequest)(nil),        // 21: gameoflife.WorkerRequest
+	(*WorkerResponse)(nil),       // 22: gameoflife.WorkerResponse
+}
+var file_gameoflife_proto_depIdxs = []int32{
+	1,  // 0: gameoflife.World.cells:type_name -> gameoflife.BitRows
+	2,  // 1: gameoflife.World.states:type_name -> gameoflife.StateRows
+	3,  // 2: gameoflife.Request.world:type_name -> gameoflife.World
+	0,  // 3: gameoflife.Request.boundary:type_name -> gameoflife.Boundary
+	3,  // 4: gameoflife.Response.next_world:type_name -> gameoflife.World
+	1,  // 5: gameoflife.RegionResponse.rows:type_name -> gameoflife.BitRows
+	3,  // 6: gameoflife.CurrentWorldResponse.world:type_name -> gameoflife.World
+	17, // 7: gameoflife.TurnDiff.flipped:type_name -> gameoflife.Cell
+	1,  // 8: gameoflife.Tile.rows:type_name -> gameoflife.BitRows
+	1,  // 9: gameoflife.WorkerRequest.in_part:type_name -> gameoflife.BitRows
+	19, // 10: gameoflife.WorkerRequest.active_tiles:type_name -> gameoflife.TileIndex
+	0,  // 11: gameoflife.WorkerRequest.boundary:type_name -> gameoflife.Boundary
+	2,  // 12: gameoflife.WorkerRequest.in_states:type_name -> gameoflife.StateRows
+	20, // 13: gameoflife.WorkerRequest.halos:type_name -> gameoflife.Tile
+	1,  // 14: gameoflife.WorkerResponse.out_part:type_name -> gameoflife.BitRows
+	20, // 15: gameoflife.WorkerResponse.tiles:type_name -> gameoflife.Tile
+	2,  // 16: gameoflife.WorkerResponse.out_states:type_name -> gameoflife.StateRows
+	5,  // 17: gameoflife.Broker.Hello:input_type -> gameoflife.Hello
+	6,  // 18: gameoflife.Broker.RunGameOfLife:input_type -> gameoflife.Request
+	4,  // 19: gameoflife.Broker.GetAliveCount:input_type -> gameoflife.Empty
+	4,  // 20: gameoflife.Broker.GetCurrentWorld:input_type -> gameoflife.Empty
+	4,  // 21: gameoflife.Broker.HaltTurns:input_type -> gameoflife.Empty
+	4,  // 22: gameoflife.Broker.PauseServer:input_type -> gameoflife.Empty
+	4,  // 23: gameoflife.Broker.KillClients:input_type -> gameoflife.Empty
+	4,  // 24: gameoflife.Broker.GetWorkerStats:input_type -> gameoflife.Empty
+	8,  // 25: gameoflife.Broker.GetAliveCountAt:input_type -> gameoflife.AliveCountAtRequest
+	9,  // 26: gameoflife.Broker.SetRegion:input_type -> gameoflife.RegionRequest
+	10, // 27: gameoflife.Broker.StampPattern:input_type -> gameoflife.StampRequest
+	4,  // 28: gameoflife.Broker.ClearWorld:input_type -> gameoflife.Empty
+	9,  // 29: gameoflife.Broker.GetRegion:input_type -> gameoflife.RegionRequest
+	16, // 30: gameoflife.Broker.WatchTurns:input_type -> gameoflife.WatchRequest
+	5,  // 31: gameoflife.Worker.Hello:input_type -> gameoflife.Hello
+	21, // 32: gameoflife.Worker.Worker:input_type -> gameoflife.WorkerRequest
+	4,  // 33: gameoflife.Worker.KillWorker:input_type -> gameoflife.Empty
+	21, // 34: gameoflife.Worker.StreamStrips:input_type -> gameoflife.WorkerRequest
+	5,  // 35: gameoflife.Broker.Hello:output_type -> gameoflife.Hello
+	7,  // 36: gameoflife.Broker.RunGameOfLife:output_type -> gameoflife.Response
+	12, // 37: gameoflife.Broker.GetAliveCount:output_type -> gameoflife.AliveCellsResponse
+	13, // 38: gameoflife.Broker.GetCurrentWorld:output_type -> gameoflife.CurrentWorldResponse
+	4,  // 39: gameoflife.Broker.HaltTurns:output_type -> gameoflife.Empty
+	14, // 40: gameoflife.Broker.PauseServer:output_type -> gameoflife.PauseServerResponse
+	4,  // 41: gameoflife.Broker.KillClients:output_type -> gameoflife.Empty
+	15, // 42: gameoflife.Broker.GetWorkerStats:output_type -> gameoflife.WorkerStatsResponse
+	12, // 43: gameoflife.Broker.GetAliveCountAt:output_type -> gameoflife.AliveCellsResponse
+	12, // 44: gameoflife.Broker.SetRegion:output_type -> gameoflife.AliveCellsResponse
+	12, // 45: gameoflife.Broker.StampPattern:output_type -> gameoflife.AliveCellsResponse
+	12, // 46: gameoflife.Broker.ClearWorld:output_type -> gameoflife.AliveCellsResponse
+	11, // 47: gameoflife.Broker.GetRegion:output_type -> gameoflife.RegionResponse
+	18, // 48: gameoflife.Broker.WatchTurns:output_type -> gameoflife.TurnDiff
+	5,  // 49: gameoflife.Worker.Hello:output_type -> gameoflife.Hello
+	22, // 50: gameoflife.Worker.Worker:output_type -> gameoflife.WorkerResponse
+	4,  // 51: gameoflife.Worker.KillWorker:output_type -> gameoflife.Empty
+	22, // 52: gameoflife.Worker.StreamStrips:output_type -> gameoflife.WorkerResponse
+	35, // [35:53] is the sub-list for method output_type
+	17, // [17:35] is the sub-list for method input_type
+	17, // [17:17] is the sub-list for extension type_name
+	17, // [17:17] is the sub-list for extension extendee
+	0,  // [0:17] is the sub-list for field type_name
+}
+
+func init() { file_gameoflife_proto_init() }
+func file_gameoflife_proto_init() {
+	if File_gameoflife_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_gameoflife_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BitRows); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gameoflife_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StateRows); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gameoflife_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*World); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gameoflife_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Empty); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gameoflife_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Hello); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gameoflife_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Request); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gameoflife_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Response); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gameoflife_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AliveCountAtRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gameoflife_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RegionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gameoflife_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StampRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gameoflife_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RegionResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gameoflife_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AliveCellsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gameoflife_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CurrentWorldResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gameoflife_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PauseServerResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gameoflife_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WorkerStatsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gameoflife_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gameoflife_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Cell); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gameoflife_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TurnDiff); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gameoflife_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TileIndex); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gameoflife_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Tile); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gameoflife_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WorkerRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gameoflife_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WorkerResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_gameoflife_proto_msgTypes[2].OneofWrappers = []interface{}{
+		(*World_Cells)(nil),
+		(*World_States)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_gameoflife_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   22,
+			NumExtensions: 0,
+			NumServices:   2,
+		},
+		GoTypes:           file_gameoflife_proto_goTypes,
+		DependencyIndexes: file_gameoflife_proto_depIdxs,
+		EnumInfos:         file_gameoflife_proto_enumTypes,
+		MessageInfos:      file_gameoflife_proto_msgTypes,
+	}.Build()
+	File_gameoflife_proto = out.File
+	file_gameoflife_proto_rawDesc = nil
+	file_gameoflife_proto_goTypes = nil
+	file_gameoflife_proto_depIdxs = nil
+}
@@ -0,0 +1,874 @@
+// The controller to broker and broker to worker protocols, the same calls as the net/rpc methods named in stubs,
+// for tooling that cannot speak gob. Fields follow the structs in stubs, and every call there has a call here of
+// the same name. WatchTurns and StreamStrips are streaming calls that net/rpc cannot make.
+//
+// The broker and workers serve these alongside net/rpc on the address given with -grpc. The controller calls the
+// broker over gRPC when given -grpc, and the broker calls its workers over gRPC when given -grpcworkers.
+// gameoflife.pb.go and gameoflife_grpc.pb.go are generated from this file by protoc-gen-go and protoc-gen-go-grpc,
+// run from this directory with
+//   protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative gameoflife.proto
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: gameoflife.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Broker_Hello_FullMethodName           = "/gameoflife.Broker/Hello"
+	Broker_RunGameOfLife_FullMethodName   = "/gameoflife.Broker/RunGameOfLife"
+	Broker_GetAliveCount_FullMethodName   = "/gameoflife.Broker/GetAliveCount"
+	Broker_GetCurrentWorld_FullMethodName = "/gameoflife.Broker/GetCurrentWorld"
+	Broker_HaltTurns_FullMethodName       = "/gameoflife.Broker/HaltTurns"
+	Broker_PauseServer_FullMethodName     = "/gameoflife.Broker/PauseServer"
+	Broker_KillClients_FullMethodName     = "/gameoflife.Broker/KillClients"
+	Broker_GetWorkerStats_FullMethodName  = "/gameoflife.Broker/GetWorkerStats"
+	Broker_GetAliveCountAt_FullMethodName = "/gameoflife.Broker/GetAliveCountAt"
+	Broker_SetRegion_FullMethodName       = "/gameoflife.Broker/SetRegion"
+	Broker_StampPattern_FullMethodName    = "/gameoflife.Broker/StampPattern"
+	Broker_ClearWorld_FullMethodName      = "/gameoflife.Broker/ClearWorld"
+	Broker_GetRegion_FullMethodName       = "/gameoflife.Broker/GetRegion"
+	Broker_WatchTurns_FullMethodName      = "/gameoflife.Broker/WatchTurns"
+)
+
+// BrokerClient is the client API for Broker service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type BrokerClient interface {
+	// the message is named in full, as inside the service Hello is the call
+	Hello(ctx context.Context, in *Hello, opts ...grpc.CallOption) (*Hello, error)
+	RunGameOfLife(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Response, error)
+	GetAliveCount(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*AliveCellsResponse, error)
+	GetCurrentWorld(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CurrentWorldResponse, error)
+	HaltTurns(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
+	PauseServer(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PauseServerResponse, error)
+	KillClients(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
+	GetWorkerStats(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*WorkerStatsResponse, error)
+	GetAliveCountAt(ctx context.Context, in *AliveCountAtRequest, opts ...grpc.CallOption) (*AliveCellsResponse, error)
+	SetRegion(ctx context.Context, in *RegionRequest, opts ...grpc.CallOption) (*AliveCellsResponse, error)
+	StampPattern(ctx context.Context, in *StampRequest, opts ...grpc.CallOption) (*AliveCellsResponse, error)
+	ClearWorld(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*AliveCellsResponse, error)
+	GetRegion(ctx context.Context, in *RegionRequest, opts ...grpc.CallOption) (*RegionResponse, error)
+	// WatchTurns streams the cells that flip on every turn from the turn it is called on, or between the turns that
+	// HashLife jumps to, so a controller can follow the world without asking for regions of it. A caller that falls
+	// 64 turns behind is dropped with RESOURCE_EXHAUSTED
+	WatchTurns(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Broker_WatchTurnsClient, error)
+}
+
+type brokerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBrokerClient(cc grpc.ClientConnInterface) BrokerClient {
+	return &brokerClient{cc}
+}
+
+func (c *brokerClient) Hello(ctx context.Context, in *Hello, opts ...grpc.CallOption) (*Hello, error) {
+	out := new(Hello)
+	err := c.cc.Invoke(ctx, Broker_Hello_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *brokerClient) RunGameOfLife(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, Broker_RunGameOfLife_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *brokerClient) GetAliveCount(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*AliveCellsResponse, error) {
+	out := new(AliveCellsResponse)
+	err := c.cc.Invoke(ctx, Broker_GetAliveCount_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *brokerClient) GetCurrentWorld(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CurrentWorldResponse, error) {
+	out := new(CurrentWorldResponse)
+	err := c.cc.Invoke(ctx, Broker_GetCurrentWorld_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *brokerClient) HaltTurns(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Broker_HaltTurns_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *brokerClient) PauseServer(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PauseServerResponse, error) {
+	out := new(PauseServerResponse)
+	err := c.cc.Invoke(ctx, Broker_PauseServer_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *brokerClient) KillClients(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Broker_KillClients_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *brokerClient) GetWorkerStats(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*WorkerStatsResponse, error) {
+	out := new(WorkerStatsResponse)
+	err := c.cc.Invoke(ctx, Broker_GetWorkerStats_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *brokerClient) GetAliveCountAt(ctx context.Context, in *AliveCountAtRequest, opts ...grpc.CallOption) (*AliveCellsResponse, error) {
+	out := new(AliveCellsResponse)
+	err := c.cc.Invoke(ctx, Broker_GetAliveCountAt_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *brokerClient) SetRegion(ctx context.Context, in *RegionRequest, opts ...grpc.CallOption) (*AliveCellsResponse, error) {
+	out := new(AliveCellsResponse)
+	err := c.cc.Invoke(ctx, Broker_SetRegion_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *brokerClient) StampPattern(ctx context.Context, in *StampRequest, opts ...grpc.CallOption) (*AliveCellsResponse, error) {
+	out := new(AliveCellsResponse)
+	err := c.cc.Invoke(ctx, Broker_StampPattern_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *brokerClient) ClearWorld(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*AliveCellsResponse, error) {
+	out := new(AliveCellsResponse)
+	err := c.cc.Invoke(ctx, Broker_ClearWorld_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *brokerClient) GetRegion(ctx context.Context, in *RegionRequest, opts ...grpc.CallOption) (*RegionResponse, error) {
+	out := new(RegionResponse)
+	err := c.cc.Invoke(ctx, Broker_GetRegion_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *brokerClient) WatchTurns(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Broker_WatchTurnsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Broker_ServiceDesc.Streams[0], Broker_WatchTurns_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &brokerWatchTurnsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Broker_WatchTurnsClient interface {
+	Recv() (*TurnDiff, error)
+	grpc.ClientStream
+}
+
+type brokerWatchTurnsClient struct {
+	grpc.ClientStream
+}
+
+func (x *brokerWatchTurnsClient) Recv() (*TurnDiff, error) {
+	m := new(TurnDiff)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// BrokerServer is the server API for Broker service.
+// All implementations must embed UnimplementedBrokerServer
+// for forward compatibility
+type BrokerServer interface {
+	// the message is named in full, as inside the service Hello is the call
+	Hello(context.Context, *Hello) (*Hello, error)
+	RunGameOfLife(context.Context, *Request) (*Response, error)
+	GetAliveCount(context.Context, *Empty) (*AliveCellsResponse, error)
+	GetCurrentWorld(context.Context, *Empty) (*CurrentWorldResponse, error)
+	HaltTurns(context.Context, *Empty) (*Empty, error)
+	PauseServer(context.Context, *Empty) (*PauseServerResponse, error)
+	KillClients(context.Context, *Empty) (*Empty, error)
+	GetWorkerStats(context.Context, *Empty) (*WorkerStatsResponse, error)
+	GetAliveCountAt(context.Context, *AliveCountAtRequest) (*AliveCellsResponse, error)
+	SetRegion(context.Context, *RegionRequest) (*AliveCellsResponse, error)
+	StampPattern(context.Context, *StampRequest) (*AliveCellsResponse, error)
+	ClearWorld(context.Context, *Empty) (*AliveCellsResponse, error)
+	GetRegion(context.Context, *RegionRequest) (*RegionResponse, error)
+	// WatchTurns streams the cells that flip on every turn from the turn it is called on, or between the turns that
+	// HashLife jumps to, so a controller can follow the world without asking for regions of it. A caller that falls
+	// 64 turns behind is dropped with RESOURCE_EXHAUSTED
+	WatchTurns(*WatchRequest, Broker_WatchTurnsServer) error
+	mustEmbedUnimplementedBrokerServer()
+}
+
+// UnimplementedBrokerServer must be embedded to have forward compatible implementations.
+type UnimplementedBrokerServer struct {
+}
+
+func (UnimplementedBrokerServer) Hello(context.Context, *Hello) (*Hello, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Hello not implemented")
+}
+func (UnimplementedBrokerServer) RunGameOfLife(context.Context, *Request) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RunGameOfLife not implemented")
+}
+func (UnimplementedBrokerServer) GetAliveCount(context.Context, *Empty) (*AliveCellsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAliveCount not implemented")
+}
+func (UnimplementedBrokerServer) GetCurrentWorld(context.Context, *Empty) (*CurrentWorldResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCurrentWorld not implemented")
+}
+func (UnimplementedBrokerServer) HaltTurns(context.Context, *Empty) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HaltTurns not implemented")
+}
+func (UnimplementedBrokerServer) PauseServer(context.Context, *Empty) (*PauseServerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseServer not implemented")
+}
+func (UnimplementedBrokerServer) KillClients(context.Context, *Empty) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method KillClients not implemented")
+}
+func (UnimplementedBrokerServer) GetWorkerStats(context.Context, *Empty) (*WorkerStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWorkerStats not implemented")
+}
+func (UnimplementedBrokerServer) GetAliveCountAt(context.Context, *AliveCountAtRequest) (*AliveCellsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAliveCountAt not implemented")
+}
+func (UnimplementedBrokerServer) SetRegion(context.Context, *RegionRequest) (*AliveCellsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetRegion not implemented")
+}
+func (UnimplementedBrokerServer) StampPattern(context.Context, *StampRequest) (*AliveCellsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StampPattern not implemented")
+}
+func (UnimplementedBrokerServer) ClearWorld(context.Context, *Empty) (*AliveCellsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClearWorld not implemented")
+}
+func (UnimplementedBrokerServer) GetRegion(context.Context, *RegionRequest) (*RegionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRegion not implemented")
+}
+func (UnimplementedBrokerServer) WatchTurns(*WatchRequest, Broker_WatchTurnsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchTurns not implemented")
+}
+func (UnimplementedBrokerServer) mustEmbedUnimplementedBrokerServer() {}
+
+// UnsafeBrokerServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BrokerServer will
+// result in compilation errors.
+type UnsafeBrokerServer interface {
+	mustEmbedUnimplementedBrokerServer()
+}
+
+func RegisterBrokerServer(s grpc.ServiceRegistrar, srv BrokerServer) {
+	s.RegisterService(&Broker_ServiceDesc, srv)
+}
+
+func _Broker_Hello_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Hello)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BrokerServer).Hello(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Broker_Hello_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BrokerServer).Hello(ctx, req.(*Hello))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Broker_RunGameOfLife_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BrokerServer).RunGameOfLife(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Broker_RunGameOfLife_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BrokerServer).RunGameOfLife(ctx, req.(*Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Broker_GetAliveCount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BrokerServer).GetAliveCount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Broker_GetAliveCount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BrokerServer).GetAliveCount(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Broker_GetCurrentWorld_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BrokerServer).GetCurrentWorld(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Broker_GetCurrentWorld_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BrokerServer).GetCurrentWorld(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Broker_HaltTurns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BrokerServer).HaltTurns(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Broker_HaltTurns_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BrokerServer).HaltTurns(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Broker_PauseServer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BrokerServer).PauseServer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Broker_PauseServer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BrokerServer).PauseServer(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Broker_KillClients_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BrokerServer).KillClients(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Broker_KillClients_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BrokerServer).KillClients(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Broker_GetWorkerStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BrokerServer).GetWorkerStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Broker_GetWorkerStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BrokerServer).GetWorkerStats(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Broker_GetAliveCountAt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AliveCountAtRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BrokerServer).GetAliveCountAt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Broker_GetAliveCountAt_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BrokerServer).GetAliveCountAt(ctx, req.(*AliveCountAtRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Broker_SetRegion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BrokerServer).SetRegion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Broker_SetRegion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BrokerServer).SetRegion(ctx, req.(*RegionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Broker_StampPattern_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StampRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BrokerServer).StampPattern(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Broker_StampPattern_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BrokerServer).StampPattern(ctx, req.(*StampRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Broker_ClearWorld_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BrokerServer).ClearWorld(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Broker_ClearWorld_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BrokerServer).ClearWorld(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Broker_GetRegion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BrokerServer).GetRegion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Broker_GetRegion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BrokerServer).GetRegion(ctx, req.(*RegionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Broker_WatchTurns_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BrokerServer).WatchTurns(m, &brokerWatchTurnsServer{stream})
+}
+
+type Broker_WatchTurnsServer interface {
+	Send(*TurnDiff) error
+	grpc.ServerStream
+}
+
+type brokerWatchTurnsServer struct {
+	grpc.ServerStream
+}
+
+func (x *brokerWatchTurnsServer) Send(m *TurnDiff) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// Broker_ServiceDesc is the grpc.ServiceDesc for Broker service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Broker_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gameoflife.Broker",
+	HandlerType: (*BrokerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Hello",
+			Handler:    _Broker_Hello_Handler,
+		},
+		{
+			MethodName: "RunGameOfLife",
+			Handler:    _Broker_RunGameOfLife_Handler,
+		},
+		{
+			MethodName: "GetAliveCount",
+			Handler:    _Broker_GetAliveCount_Handler,
+		},
+		{
+			MethodName: "GetCurrentWorld",
+			Handler:    _Broker_GetCurrentWorld_Handler,
+		},
+		{
+			MethodName: "HaltTurns",
+			Handler:    _Broker_HaltTurns_Handler,
+		},
+		{
+			MethodName: "PauseServer",
+			Handler:    _Broker_PauseServer_Handler,
+		},
+		{
+			MethodName: "KillClients",
+			Handler:    _Broker_KillClients_Handler,
+		},
+		{
+			MethodName: "GetWorkerStats",
+			Handler:    _Broker_GetWorkerStats_Handler,
+		},
+		{
+			MethodName: "GetAliveCountAt",
+			Handler:    _Broker_GetAliveCountAt_Handler,
+		},
+		{
+			MethodName: "SetRegion",
+			Handler:    _Broker_SetRegion_Handler,
+		},
+		{
+			MethodName: "StampPattern",
+			Handler:    _Broker_StampPattern_Handler,
+		},
+		{
+			MethodName: "ClearWorld",
+			Handler:    _Broker_ClearWorld_Handler,
+		},
+		{
+			MethodName: "GetRegion",
+			Handler:    _Broker_GetRegion_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchTurns",
+			Handler:       _Broker_WatchTurns_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "gameoflife.proto",
+}
+
+const (
+	Worker_Hello_FullMethodName        = "/gameoflife.Worker/Hello"
+	Worker_Worker_FullMethodName       = "/gameoflife.Worker/Worker"
+	Worker_KillWorker_FullMethodName   = "/gameoflife.Worker/KillWorker"
+	Worker_StreamStrips_FullMethodName = "/gameoflife.Worker/StreamStrips"
+)
+
+// WorkerClient is the client API for Worker service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type WorkerClient interface {
+	Hello(ctx context.Context, in *Hello, opts ...grpc.CallOption) (*Hello, error)
+	Worker(ctx context.Context, in *WorkerRequest, opts ...grpc.CallOption) (*WorkerResponse, error)
+	KillWorker(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
+	// StreamStrips keeps one stream open for a run, with a request and response for each turn, so that the strip
+	// does not need a new call every turn
+	StreamStrips(ctx context.Context, opts ...grpc.CallOption) (Worker_StreamStripsClient, error)
+}
+
+type workerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewWorkerClient(cc grpc.ClientConnInterface) WorkerClient {
+	return &workerClient{cc}
+}
+
+func (c *workerClient) Hello(ctx context.Context, in *Hello, opts ...grpc.CallOption) (*Hello, error) {
+	out := new(Hello)
+	err := c.cc.Invoke(ctx, Worker_Hello_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workerClient) Worker(ctx context.Context, in *WorkerRequest, opts ...grpc.CallOption) (*WorkerResponse, error) {
+	out := new(WorkerResponse)
+	err := c.cc.Invoke(ctx, Worker_Worker_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workerClient) KillWorker(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Worker_KillWorker_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workerClient) StreamStrips(ctx context.Context, opts ...grpc.CallOption) (Worker_StreamStripsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Worker_ServiceDesc.Streams[0], Worker_StreamStrips_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &workerStreamStripsClient{stream}
+	return x, nil
+}
+
+type Worker_StreamStripsClient interface {
+	Send(*WorkerRequest) error
+	Recv() (*WorkerResponse, error)
+	grpc.ClientStream
+}
+
+type workerStreamStripsClient struct {
+	grpc.ClientStream
+}
+
+func (x *workerStreamStripsClient) Send(m *WorkerRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *workerStreamStripsClient) Recv() (*WorkerResponse, error) {
+	m := new(WorkerResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// WorkerServer is the server API for Worker service.
+// All implementations must embed UnimplementedWorkerServer
+// for forward compatibility
+type WorkerServer interface {
+	Hello(context.Context, *Hello) (*Hello, error)
+	Worker(context.Context, *WorkerRequest) (*WorkerResponse, error)
+	KillWorker(context.Context, *Empty) (*Empty, error)
+	// StreamStrips keeps one stream open for a run, with a request and response for each turn, so that the strip
+	// does not need a new call every turn
+	StreamStrips(Worker_StreamStripsServer) error
+	mustEmbedUnimplementedWorkerServer()
+}
+
+// UnimplementedWorkerServer must be embedded to have forward compatible implementations.
+type UnimplementedWorkerServer struct {
+}
+
+func (UnimplementedWorkerServer) Hello(context.Context, *Hello) (*Hello, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Hello not implemented")
+}
+func (UnimplementedWorkerServer) Worker(context.Context, *WorkerRequest) (*WorkerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Worker not implemented")
+}
+func (UnimplementedWorkerServer) KillWorker(context.Context, *Empty) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method KillWorker not implemented")
+}
+func (UnimplementedWorkerServer) StreamStrips(Worker_StreamStripsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamStrips not implemented")
+}
+func (UnimplementedWorkerServer) mustEmbedUnimplementedWorkerServer() {}
+
+// UnsafeWorkerServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to WorkerServer will
+// result in compilation errors.
+type UnsafeWorkerServer interface {
+	mustEmbedUnimplementedWorkerServer()
+}
+
+func RegisterWorkerServer(s grpc.ServiceRegistrar, srv WorkerServer) {
+	s.RegisterService(&Worker_ServiceDesc, srv)
+}
+
+func _Worker_Hello_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Hello)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerServer).Hello(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Worker_Hello_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerServer).Hello(ctx, req.(*Hello))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Worker_Worker_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WorkerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerServer).Worker(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Worker_Worker_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerServer).Worker(ctx, req.(*WorkerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Worker_KillWorker_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerServer).KillWorker(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Worker_KillWorker_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerServer).KillWorker(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Worker_StreamStrips_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(WorkerServer).StreamStrips(&workerStreamStripsServer{stream})
+}
+
+type Worker_StreamStripsServer interface {
+	Send(*WorkerResponse) error
+	Recv() (*WorkerRequest, error)
+	grpc.ServerStream
+}
+
+type workerStreamStripsServer struct {
+	grpc.ServerStream
+}
+
+func (x *workerStreamStripsServer) Send(m *WorkerResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *workerStreamStripsServer) Recv() (*WorkerRequest, error) {
+	m := new(WorkerRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Worker_ServiceDesc is the grpc.ServiceDesc for Worker service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Worker_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gameoflife.Worker",
+	HandlerType: (*WorkerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Hello",
+			Handler:    _Worker_Hello_Handler,
+		},
+		{
+			MethodName: "Worker",
+			Handler:    _Worker_Worker_Handler,
+		},
+		{
+			MethodName: "KillWorker",
+			Handler:    _Worker_KillWorker_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamStrips",
+			Handler:       _Worker_StreamStrips_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "gameoflife.proto",
+}
@@ -0,0 +1,235 @@
+// Package rle reads and writes patterns in the run length encoded format used by Golly and LifeWiki, including the
+// letters that extended RLE uses for the states of rules with more than two states.
+package rle
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// lineLength is the longest line Encode writes, the same as Golly
+const lineLength = 70
+
+// MaxSize is the largest width or height of a pattern, the same as the largest worlds, which stops a bad header or
+// run count asking for a huge grid
+const MaxSize = 1 << 14
+
+// Pattern is a decoded pattern, with the state of every cell of its bounding box
+type Pattern struct {
+	Width, Height int
+	Rule          string    // the rule given in the header, empty if there is none
+	States        [][]uint8 // States[y][x] is the state of the cell x, y, with 0 dead
+
+	maxWidth, maxHeight int // the largest the pattern may grow to
+}
+
+// At returns the state of the cell x, y
+func (p *Pattern) At(x, y int) uint8 {
+	return p.States[y][x]
+}
+
+// MaxState returns the highest state of any cell, which is 1 for a pattern that only has alive and dead cells
+func (p *Pattern) MaxState() uint8 {
+	max := uint8(0)
+	for _, row := range p.States {
+		for _, state := range row {
+			if state > max {
+				max = state
+			}
+		}
+	}
+	return max
+}
+
+// Parse decodes a pattern. Lines starting with # are comments, and the header line x = m, y = n, rule = r is
+// optional, with the pattern growing to fit its cells when it is left out or too small. The runs use b or . for
+// dead cells, o for alive cells, A to X for states 1 to 24, p to y followed by A to X for the states above 24,
+// $ for the end of a row and ! for the end of the pattern
+func Parse(s string) (*Pattern, error) {
+	return ParseWithin(s, MaxSize, MaxSize)
+}
+
+// ParseWithin decodes a pattern as Parse does, but refuses one that is wider than width or taller than height, from
+// its header or its cells, before making room for it
+func ParseWithin(s string, width, height int) (*Pattern, error) {
+	p := &Pattern{maxWidth: width, maxHeight: height}
+	var body strings.Builder
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "" || strings.HasPrefix(line, "#"):
+		case strings.HasPrefix(line, "x"):
+			if err := p.header(line); err != nil {
+				return nil, err
+			}
+		default:
+			body.WriteString(line)
+		}
+	}
+
+	text := body.String()
+	x, y, run := 0, 0, 0
+	prefix := byte(0)
+	for i := 0; i < len(text); i++ {
+		b := text[i]
+		if b >= '0' && b <= '9' {
+			run = run*10 + int(b-'0')
+			if run > MaxSize {
+				return nil, fmt.Errorf("run of %d is longer than %d", run, MaxSize)
+			}
+			continue
+		}
+		if run == 0 {
+			run = 1
+		}
+		if prefix != 0 && (b < 'A' || b > 'X') {
+			return nil, fmt.Errorf("%q should be followed by a state from A to X, not %q", prefix, b)
+		}
+		switch {
+		case b == '!':
+			return p, nil
+		case b == '$':
+			x, y = 0, y+run
+		case b >= 'p' && b <= 'y':
+			prefix = b
+			continue
+		case b == 'b' || b == '.' || b == 'o' || (b >= 'A' && b <= 'X'):
+			state := uint8(0)
+			switch {
+			case b == 'o':
+				state = 1
+			case b >= 'A' && b <= 'X':
+				state = b - 'A' + 1
+				if prefix != 0 {
+					if 24*int(prefix-'p'+1)+int(state) > 255 {
+						return nil, fmt.Errorf("state %c%c is above 255", prefix, b)
+					}
+					state += 24 * (prefix - 'p' + 1)
+				}
+			}
+			if state != 0 {
+				if err := p.grow(x+run, y+1); err != nil {
+					return nil, err
+				}
+				for j := 0; j < run; j++ {
+					p.States[y][x+j] = state
+				}
+			}
+			x += run
+		case b == ' ' || b == '\t' || b == '\r':
+		default:
+			return nil, fmt.Errorf("unexpected %q in the pattern", b)
+		}
+		run, prefix = 0, 0
+	}
+	return p, nil
+}
+
+// header reads the x = m, y = n, rule = r line, sizing the pattern to m by n
+func (p *Pattern) header(line string) error {
+	width, height := 0, 0
+	for _, field := range strings.Split(line, ",") {
+		parts := strings.SplitN(field, "=", 2)
+		if len(parts) != 2 {
+			return fmt.Errorf("header field %q should be name = value", strings.TrimSpace(field))
+		}
+		name, value := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+		var err error
+		switch name {
+		case "x":
+			width, err = strconv.Atoi(value)
+		case "y":
+			height, err = strconv.Atoi(value)
+		case "rule":
+			p.Rule = value
+		}
+		if err != nil || width < 0 || height < 0 {
+			return fmt.Errorf("header field %s = %s should be a size", name, value)
+		}
+	}
+	return p.grow(width, height)
+}
+
+// grow makes the pattern at least width by height, keeping the cells it has
+func (p *Pattern) grow(width, height int) error {
+	if width > p.maxWidth || height > p.maxHeight {
+		return fmt.Errorf("pattern of %dx%d is larger than %dx%d", width, height, p.maxWidth, p.maxHeight)
+	}
+	if width > p.Width {
+		for y, row := range p.States {
+			p.States[y] = append(row, make([]uint8, width-p.Width)...)
+		}
+		p.Width = width
+	}
+	for p.Height < height {
+		p.States = append(p.States, make([]uint8, p.Width))
+		p.Height++
+	}
+	return nil
+}
+
+// symbol returns the letters for a state, using b and o when the pattern only has dead and alive cells
+func symbol(state uint8, twoStates bool) string {
+	switch {
+	case twoStates && state == 0:
+		return "b"
+	case twoStates:
+		return "o"
+	case state == 0:
+		return "."
+	case state <= 24:
+		return string(rune('A' + state - 1))
+	default:
+		return string([]byte{'p' + (state-25)/24, 'A' + (state-25)%24})
+	}
+}
+
+// Encode writes the pattern with a header, leaving out the dead cells at the end of each row and the empty rows at
+// the end of the pattern. Lines are wrapped at 70 characters
+func (p *Pattern) Encode() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "x = %d, y = %d", p.Width, p.Height)
+	if p.Rule != "" {
+		fmt.Fprintf(&b, ", rule = %s", p.Rule)
+	}
+	b.WriteString("\n")
+
+	twoStates := p.MaxState() <= 1
+	line := 0
+	write := func(run int, letters string) {
+		token := letters
+		if run > 1 {
+			token = strconv.Itoa(run) + letters
+		}
+		if line+len(token) > lineLength {
+			b.WriteString("\n")
+			line = 0
+		}
+		b.WriteString(token)
+		line += len(token)
+	}
+	rows := 0 // rows ended that have not been written, so that empty rows become one run of $
+	for _, row := range p.States {
+		end := len(row)
+		for end > 0 && row[end-1] == 0 {
+			end--
+		}
+		if end > 0 && rows > 0 {
+			write(rows, "$")
+			rows = 0
+		}
+		for x := 0; x < end; {
+			run := 1
+			for x+run < end && row[x+run] == row[x] {
+				run++
+			}
+			write(run, symbol(row[x], twoStates))
+			x += run
+		}
+		rows++
+	}
+	write(1, "!")
+	b.WriteString("\n")
+	return b.String()
+}
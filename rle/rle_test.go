@@ -0,0 +1,103 @@
+package rle
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestParse checks a glider with a header and comments, and the same glider without them
+func TestParse(t *testing.T) {
+	glider := [][]uint8{
+		{0, 1, 0},
+		{0, 0, 1},
+		{1, 1, 1},
+	}
+	tests := map[string]string{
+		"with a header":    "#N Glider\n#C a comment\nx = 3, y = 3, rule = B3/S23\nbo$2bo$3o!\n",
+		"without a header": "bo$2bo$3o!",
+		"split over lines": "x = 3, y = 3\nb\no$2b\no$\n3o!",
+		"dots":             "x=3,y=3\n.o$2.o$3o!",
+	}
+	for name, text := range tests {
+		p, err := Parse(text)
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		if p.Width != 3 || p.Height != 3 {
+			t.Errorf("%s: size is %dx%d, want 3x3", name, p.Width, p.Height)
+			continue
+		}
+		for y := range glider {
+			for x := range glider[y] {
+				if p.At(x, y) != glider[y][x] {
+					t.Errorf("%s: cell %d,%d is %d, want %d", name, x, y, p.At(x, y), glider[y][x])
+				}
+			}
+		}
+	}
+}
+
+// TestParseStates checks the letters used for the states of rules with more than two states
+func TestParseStates(t *testing.T) {
+	p, err := Parse("x = 6, y = 3, rule = B2/S/C3\n2.AB$\n$.pA2yO!")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Rule != "B2/S/C3" {
+		t.Errorf("rule is %q, want B2/S/C3", p.Rule)
+	}
+	want := map[[2]int]uint8{{2, 0}: 1, {3, 0}: 2, {1, 2}: 25, {2, 2}: 255, {3, 2}: 255}
+	for y := 0; y < p.Height; y++ {
+		for x := 0; x < p.Width; x++ {
+			if p.At(x, y) != want[[2]int{x, y}] {
+				t.Errorf("cell %d,%d is %d, want %d", x, y, p.At(x, y), want[[2]int{x, y}])
+			}
+		}
+	}
+	if p.MaxState() != 255 {
+		t.Errorf("highest state is %d, want 255", p.MaxState())
+	}
+}
+
+// TestParseErrors checks that broken patterns are refused
+func TestParseErrors(t *testing.T) {
+	for _, bad := range []string{"x = a, y = 3\no!", "x = 3 y = 3\no!", "3z!", "pb!", "zA!", "99999999o!", "x = 20000, y = 1\n!"} {
+		if _, err := Parse(bad); err == nil {
+			t.Errorf("Parse(%q) should fail", bad)
+		}
+	}
+}
+
+// TestParseWithin checks that patterns larger than the limit are refused, whether the header or the cells make them so
+func TestParseWithin(t *testing.T) {
+	if p, err := ParseWithin("x = 3, y = 3\nbo$2bo$3o!", 3, 3); err != nil || p.Width != 3 || p.Height != 3 {
+		t.Errorf("a glider within 3x3 parsed as %v, %v", p, err)
+	}
+	for _, bad := range []string{"x = 4, y = 3\nbo$2bo$3o!", "bo$2bo$4o!", "3$o!", "x = 16384, y = 16384\n!"} {
+		if _, err := ParseWithin(bad, 3, 3); err == nil {
+			t.Errorf("ParseWithin(%q) should fail for a 3x3 limit", bad)
+		}
+	}
+}
+
+// TestEncode checks that patterns are written the way Golly writes them and read back unchanged
+func TestEncode(t *testing.T) {
+	tests := map[string]string{
+		"glider":       "x = 3, y = 3, rule = B3/S23\nbo$2bo$3o!\n",
+		"empty rows":   "x = 4, y = 5\n2o4$3bo!\n",
+		"states":       "x = 6, y = 3, rule = B2/S/C3\n2.AB2$.pA2yO!\n",
+		"empty":        "x = 2, y = 2\n!\n",
+		"long wrapped": "x = 140, y = 1\n" + strings.Repeat("bo", 35) + "\n" + strings.Repeat("bo", 35) + "\n!\n",
+	}
+	for name, text := range tests {
+		p, err := Parse(text)
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		if got := p.Encode(); got != text {
+			t.Errorf("%s: encoded as %q, want %q", name, got, text)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package rule
+
+import (
+	"image/color"
+	"uk.ac.bris.cs/gameoflife/util"
+)
+
+// Coloured is implemented by rules that choose the colour of each state in images. Other rules draw dead cells black
+// and alive cells white, with dying cells fading from one to the other
+type Coloured interface {
+	Colour(state uint8) color.RGBA
+}
+
+// Colour returns the colour a state of a rule is drawn in
+func Colour(r Rule, state uint8) color.RGBA {
+	if c, ok := r.(Coloured); ok {
+		return c.Colour(state)
+	}
+	grey := util.StateToGrey(state, r.States())
+	return color.RGBA{R: grey, G: grey, B: grey, A: 255}
+}
+
+// Grey returns the grey level a state of a rule is written as in a PGM image, which is the luminance of its colour
+func Grey(r Rule, state uint8) uint8 {
+	if _, ok := r.(Coloured); !ok {
+		return util.StateToGrey(state, r.States())
+	}
+	c := Colour(r, state)
+	return uint8((299*int(c.R) + 587*int(c.G) + 114*int(c.B)) / 1000)
+}
+
+// FromGrey returns the state of a rule whose grey level is closest to a grey level read from a PGM image
+func FromGrey(r Rule, grey uint8) uint8 {
+	if _, ok := r.(Coloured); !ok {
+		return util.GreyToState(grey, r.States())
+	}
+	best, bestDistance := uint8(0), 256
+	for state := 0; state < r.States(); state++ {
+		distance := int(Grey(r, uint8(state))) - int(grey)
+		if distance < 0 {
+			distance = -distance
+		}
+		if distance < bestDistance {
+			best, bestDistance = uint8(state), distance
+		}
+	}
+	return best
+}
@@ -0,0 +1,90 @@
+package rule
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+)
+
+// MaxFixedStates is the most states a Fixed automaton may have
+const MaxFixedStates = 8
+
+// Fixed is an automaton with its own states and transition function, such as Wireworld, which is looked up by name
+// instead of being parsed from a rule string
+type Fixed struct {
+	Name    string
+	Colours []color.RGBA // the colour of each state in images, which also sets the number of states
+	// Transition returns the next state of a cell from its state and the number of its Moore neighbours in each state
+	Transition func(state uint8, neighbours *[MaxFixedStates]int) uint8
+}
+
+// fixed are the automata that can be run by name, keyed by their lower case names
+var fixed = map[string]Fixed{}
+
+// Register makes a Fixed automaton available to Parse by its name. The controller, broker and workers must all
+// register the same automata, so it is best done from an init function of a package they all import
+func Register(f Fixed) {
+	if len(f.Colours) < 2 || len(f.Colours) > MaxFixedStates {
+		panic(fmt.Sprintf("%s must have between 2 and %d states", f.Name, MaxFixedStates))
+	}
+	fixed[strings.ToLower(f.Name)] = f
+}
+
+func init() {
+	Register(Wireworld)
+}
+
+// Wireworld simulates digital logic with electrons moving along wires. An empty cell (0) never changes, an
+// electron head (1) becomes a tail (2), a tail becomes a conductor (3), and a conductor becomes a head when one or
+// two of its neighbours are heads
+var Wireworld = Fixed{
+	Name: "wireworld",
+	// pale blue heads, red tails and amber conductors, which are grey levels 208, 85 and 160 in a PGM image
+	Colours: []color.RGBA{
+		{0, 0, 0, 255},
+		{160, 224, 255, 255},
+		{224, 32, 0, 255},
+		{224, 160, 0, 255},
+	},
+	Transition: func(state uint8, neighbours *[MaxFixedStates]int) uint8 {
+		switch state {
+		case 1:
+			return 2
+		case 2:
+			return 3
+		case 3:
+			if neighbours[1] == 1 || neighbours[1] == 2 {
+				return 1
+			}
+			return 3
+		}
+		return 0
+	},
+}
+
+func (f Fixed) String() string {
+	return f.Name
+}
+
+func (f Fixed) States() int {
+	return len(f.Colours)
+}
+
+func (f Fixed) Radius() int {
+	return 1
+}
+
+// Next counts the Moore neighbours of the cell x, y in each state and applies the transition function
+func (f Fixed) Next(grid Grid, x, y int) uint8 {
+	var neighbours [MaxFixedStates]int
+	for _, o := range ring {
+		if state := grid.State(x+o.dx, y+o.dy); int(state) < len(f.Colours) {
+			neighbours[state]++
+		}
+	}
+	return f.Transition(grid.State(x, y), &neighbours)
+}
+
+func (f Fixed) Colour(state uint8) color.RGBA {
+	return f.Colours[state]
+}
@@ -0,0 +1,211 @@
+package rule
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Generations is a Life-like rule where cells that die first pass through C-2 dying states. Only alive cells
+// (state 1) count as neighbours, and with C = 2 it is an ordinary Life-like rule
+type Generations struct {
+	Birth, Survival [9]bool       // whether a cell is born or survives with each number of alive neighbours
+	C               int           // number of states
+	Neighbourhood   Neighbourhood // radius 1 Moore, VonNeumann or Hexagonal, or Weighted
+	Weights         []int         // the mask of a Weighted neighbourhood, row by row
+}
+
+// parseGenerations parses B/S/C notation such as B2/S345/C4 in any order, or the S/B/C notation used by Golly
+// such as 345/2/4. The neighbourhood is either a Golly suffix, H for hexagonal or V for von Neumann as in B2/S34H,
+// or a section such as /NV or /NW111101111 for a weighted mask
+func parseGenerations(s string) (Rule, error) {
+	g := Generations{C: 2}
+	parts := strings.Split(strings.ToUpper(s), "/")
+	last := parts[len(parts)-1]
+	if last != "" && last[0] == 'N' {
+		if err := g.setNeighbourhood(last[1:]); err != nil {
+			return nil, fmt.Errorf("rule %q: %v", s, err)
+		}
+		parts = parts[:len(parts)-1]
+	} else if n := len(last); n > 0 && (last[n-1] == 'H' || last[n-1] == 'V') {
+		g.Neighbourhood, _ = parseNeighbourhood(last[n-1:])
+		parts[len(parts)-1] = last[:n-1]
+	}
+	if len(parts) < 2 || len(parts) > 3 {
+		return nil, fmt.Errorf("rule %q should be B/S or B/S/C", s)
+	}
+
+	// Golly's numeric form has no letters and puts survival first
+	if isDigits(parts[0]) && isDigits(parts[1]) && (len(parts) == 2 || isDigits(parts[2])) {
+		if err := setCounts(&g.Survival, parts[0]); err != nil {
+			return nil, err
+		}
+		if err := setCounts(&g.Birth, parts[1]); err != nil {
+			return nil, err
+		}
+		if len(parts) == 3 {
+			c, err := strconv.Atoi(parts[2])
+			if err != nil {
+				return nil, fmt.Errorf("rule %q has an invalid number of states", s)
+			}
+			g.C = c
+		}
+		return g.validate(s)
+	}
+
+	seen := map[byte]bool{}
+	for _, part := range parts {
+		if part == "" {
+			return nil, fmt.Errorf("rule %q has an empty section", s)
+		}
+		letter := strings.ToUpper(part[:1])[0]
+		if seen[letter] {
+			return nil, fmt.Errorf("rule %q has more than one %c section", s, letter)
+		}
+		seen[letter] = true
+		var err error
+		switch letter {
+		case 'B':
+			err = setCounts(&g.Birth, part[1:])
+		case 'S':
+			err = setCounts(&g.Survival, part[1:])
+		case 'C', 'G':
+			g.C, err = strconv.Atoi(part[1:])
+		default:
+			err = fmt.Errorf("rule %q has an unknown section %q", s, part)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !seen['B'] || !seen['S'] {
+		return nil, fmt.Errorf("rule %q needs both B and S sections", s)
+	}
+	return g.validate(s)
+}
+
+// setNeighbourhood sets the neighbourhood from the letters after N, along with the weights of a Weighted mask
+func (g *Generations) setNeighbourhood(s string) error {
+	var err error
+	if g.Neighbourhood, err = parseNeighbourhood(s); err != nil {
+		return err
+	}
+	if g.Neighbourhood == Weighted {
+		g.Weights, err = parseWeights(s[1:])
+	}
+	return err
+}
+
+// isDigits reports whether a string is only made of digits, including the empty string
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// setCounts marks each digit of a string as a neighbour count
+func setCounts(counts *[9]bool, digits string) error {
+	for _, r := range digits {
+		if r < '0' || r > '8' {
+			return fmt.Errorf("neighbour count %q must be between 0 and 8", r)
+		}
+		counts[r-'0'] = true
+	}
+	return nil
+}
+
+// validate checks that the number of states fits in a byte, returning the rule if it does
+func (g Generations) validate(s string) (Rule, error) {
+	if g.C < 2 || g.C > 256 {
+		return nil, fmt.Errorf("rule %q must have between 2 and 256 states", s)
+	}
+	return g, nil
+}
+
+func (g Generations) States() int {
+	return g.C
+}
+
+func (g Generations) Radius() int {
+	if g.Neighbourhood == Weighted {
+		return int(math.Sqrt(float64(len(g.Weights)))) / 2
+	}
+	return 1
+}
+
+// Next applies the rule to the cell x, y using the alive cells of its neighbourhood
+func (g Generations) Next(grid Grid, x, y int) uint8 {
+	state := grid.State(x, y)
+	if state > 1 { // dying cells carry on dying regardless of their neighbours
+		return uint8((int(state) + 1) % g.C)
+	}
+	alive := 0
+	if g.Neighbourhood == Weighted {
+		r := g.Radius()
+		side := 2*r + 1
+		for dy := -r; dy <= r; dy++ {
+			for dx := -r; dx <= r; dx++ {
+				if w := g.Weights[(dy+r)*side+dx+r]; w != 0 && grid.State(x+dx, y+dy) == 1 {
+					alive += w
+				}
+			}
+		}
+	} else {
+		for dy := -1; dy <= 1; dy++ {
+			from, to := g.Neighbourhood.span(dy, 1)
+			for dx := from; dx <= to; dx++ {
+				if (dx != 0 || dy != 0) && grid.State(x+dx, y+dy) == 1 {
+					alive++
+				}
+			}
+		}
+	}
+	counted := alive < len(g.Birth) // only weighted masks can count past 8, where no cell is born or survives
+	if state == 0 {
+		if counted && g.Birth[alive] {
+			return 1
+		}
+		return 0
+	}
+	if counted && g.Survival[alive] {
+		return 1
+	}
+	return uint8(2 % g.C)
+}
+
+// String returns the rule in B/S/C notation, leaving out C when there are only two states and ending with the
+// neighbourhood when it is not Moore
+func (g Generations) String() string {
+	var b strings.Builder
+	b.WriteString("B")
+	for i, born := range g.Birth {
+		if born {
+			b.WriteString(strconv.Itoa(i))
+		}
+	}
+	b.WriteString("/S")
+	for i, survives := range g.Survival {
+		if survives {
+			b.WriteString(strconv.Itoa(i))
+		}
+	}
+	if g.C > 2 {
+		b.WriteString("/C" + strconv.Itoa(g.C))
+	}
+	switch g.Neighbourhood {
+	case VonNeumann:
+		b.WriteString("V")
+	case Hexagonal:
+		b.WriteString("H")
+	case Weighted:
+		b.WriteString("/NW")
+		for _, w := range g.Weights {
+			b.WriteString(strconv.Itoa(w))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,258 @@
+package rule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ring is the offset of each neighbour, going clockwise from north. Bit i of a neighbourhood is ring[i]
+var ring = [8]struct{ dx, dy int }{{0, -1}, {1, -1}, {1, 0}, {1, 1}, {0, 1}, {-1, 1}, {-1, 0}, {-1, -1}}
+
+// henselLetters are the letters of each number of neighbours up to 4 in Hensel notation. 5 to 7 neighbours use the
+// letters of 3 to 1, with each configuration being the complement of the one it shares a letter with
+var henselLetters = [5]string{"", "ce", "cekain", "cekainyqjr", "cekainyqjrtwz"}
+
+// henselShapes is one neighbourhood of each letter, as the neighbours from ring that are alive
+var henselShapes = map[string][]int{
+	"1c": {1}, "1e": {0},
+	"2c": {1, 3}, "2e": {0, 2}, "2k": {0, 3}, "2a": {0, 1}, "2i": {0, 4}, "2n": {1, 5},
+	"3c": {1, 3, 5}, "3e": {0, 2, 4}, "3k": {0, 2, 5}, "3a": {0, 1, 2}, "3i": {0, 1, 7},
+	"3n": {0, 1, 3}, "3y": {0, 3, 5}, "3q": {0, 1, 5}, "3j": {0, 1, 6}, "3r": {0, 1, 4},
+	"4c": {1, 3, 5, 7}, "4e": {0, 2, 4, 6}, "4k": {0, 1, 3, 6}, "4a": {0, 1, 2, 3}, "4i": {0, 1, 3, 4},
+	"4n": {0, 1, 3, 7}, "4y": {0, 1, 3, 5}, "4q": {0, 1, 2, 5}, "4j": {0, 1, 4, 6}, "4r": {0, 1, 2, 4},
+	"4t": {0, 1, 4, 7}, "4w": {0, 1, 5, 6}, "4z": {0, 1, 4, 5},
+}
+
+// configuration is a number of alive neighbours and the Hensel letter of their arrangement, which is 0 for 0 and 8
+type configuration struct {
+	count  int
+	letter byte
+}
+
+// henselClass is the configuration of each of the 256 neighbourhoods
+var henselClass = func() (classes [256]configuration) {
+	for n := 0; n <= 8; n++ {
+		letters := henselLetters[minInt(n, 8-n)]
+		if letters == "" {
+			classes[fullMask(n)].count = n
+			continue
+		}
+		for i := range letters {
+			var shape uint8
+			for _, neighbour := range henselShapes[strconv.Itoa(minInt(n, 8-n))+letters[i:i+1]] {
+				shape |= 1 << uint(neighbour)
+			}
+			if n > 4 {
+				shape = ^shape
+			}
+			for _, symmetric := range symmetries(shape) {
+				classes[symmetric].count, classes[symmetric].letter = n, letters[i]
+			}
+		}
+	}
+	return
+}()
+
+// fullMask is the only neighbourhood with no letter for 0 or 8 neighbours
+func fullMask(n int) uint8 {
+	if n == 8 {
+		return 0xff
+	}
+	return 0
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// symmetries returns a neighbourhood under each of the four rotations, with and without a reflection
+func symmetries(shape uint8) []uint8 {
+	var all []uint8
+	for k := 0; k < 8; k += 2 {
+		var rotated, reflected uint8
+		for i := 0; i < 8; i++ {
+			if shape&(1<<uint(i)) != 0 {
+				rotated |= 1 << uint((i+k)%8)
+				reflected |= 1 << uint((k-i+8)%8)
+			}
+		}
+		all = append(all, rotated, reflected)
+	}
+	return all
+}
+
+// Isotropic is a rule in Hensel notation such as B2-a/S12, where whether a cell is born or survives depends on the
+// arrangement of its alive neighbours up to rotation and reflection, not just how many there are
+type Isotropic struct {
+	C    int       // number of states, with dying states as in Generations
+	next [512]bool // whether the cell is alive next turn, indexed by the cell itself in bit 8 and its neighbourhood
+}
+
+// isHensel reports whether a rule string has letters after the neighbour counts of its B or S sections
+func isHensel(s string) bool {
+	for _, part := range strings.Split(s, "/") {
+		if part != "" && strings.ContainsAny(part[:1], "BbSs") && strings.ContainsAny(part[1:], "-"+henselLetters[4]) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseIsotropic parses Hensel notation such as B2-a/S12 or B3/S2-i34q/C3. Each neighbour count may be followed by
+// the letters of the configurations it allows, or by a minus and the letters of those it does not
+func parseIsotropic(s string) (Rule, error) {
+	r := Isotropic{C: 2}
+	seen := map[byte]bool{}
+	for _, part := range strings.Split(s, "/") {
+		if part == "" {
+			return nil, fmt.Errorf("rule %q has an empty section", s)
+		}
+		letter := strings.ToUpper(part[:1])[0]
+		if seen[letter] {
+			return nil, fmt.Errorf("rule %q has more than one %c section", s, letter)
+		}
+		seen[letter] = true
+		var err error
+		switch letter {
+		case 'B':
+			err = r.setConfigurations(0, part[1:])
+		case 'S':
+			err = r.setConfigurations(256, part[1:])
+		case 'C', 'G':
+			r.C, err = strconv.Atoi(part[1:])
+		default:
+			err = fmt.Errorf("unknown section %q", part)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("rule %q: %v", s, err)
+		}
+	}
+	if !seen['B'] || !seen['S'] {
+		return nil, fmt.Errorf("rule %q needs both B and S sections", s)
+	}
+	if r.C < 2 || r.C > 256 {
+		return nil, fmt.Errorf("rule %q must have between 2 and 256 states", s)
+	}
+	return r, nil
+}
+
+// setConfigurations sets the entries of the table from offset for the configurations a section allows
+func (r *Isotropic) setConfigurations(offset int, section string) error {
+	for i := 0; i < len(section); {
+		if section[i] < '0' || section[i] > '8' {
+			return fmt.Errorf("neighbour count %q must be between 0 and 8", section[i])
+		}
+		count := int(section[i] - '0')
+		i++
+		negate := i < len(section) && section[i] == '-'
+		if negate {
+			i++
+		}
+		start := i
+		for i < len(section) && (section[i] < '0' || section[i] > '9') {
+			i++
+		}
+		letters := section[start:i]
+		for _, l := range letters {
+			if !strings.ContainsRune(henselLetters[minInt(count, 8-count)], l) {
+				return fmt.Errorf("%d neighbours have no configuration %c", count, l)
+			}
+		}
+		if negate && letters == "" {
+			return fmt.Errorf("%d- should be followed by the configurations to leave out", count)
+		}
+		for mask, class := range henselClass {
+			if class.count == count && (letters == "" || (strings.IndexByte(letters, class.letter) >= 0) != negate) {
+				r.next[offset+mask] = true
+			}
+		}
+	}
+	return nil
+}
+
+func (r Isotropic) States() int {
+	return r.C
+}
+
+func (r Isotropic) Radius() int {
+	return 1
+}
+
+// Next looks up the next state of the cell x, y from the alive cells of its Moore neighbourhood
+func (r Isotropic) Next(grid Grid, x, y int) uint8 {
+	state := grid.State(x, y)
+	if state > 1 { // dying cells carry on dying regardless of their neighbours
+		return uint8((int(state) + 1) % r.C)
+	}
+	index := int(state) << 8
+	for i, o := range ring {
+		if grid.State(x+o.dx, y+o.dy) == 1 {
+			index |= 1 << uint(i)
+		}
+	}
+	if r.next[index] {
+		return 1
+	}
+	if state == 0 {
+		return 0
+	}
+	return uint8(2 % r.C)
+}
+
+// String returns the rule in Hensel notation, writing each count with whichever of its letters or the letters it
+// leaves out is shorter, and only the count when it allows every configuration
+func (r Isotropic) String() string {
+	var b strings.Builder
+	b.WriteString("B")
+	r.writeSection(&b, 0)
+	b.WriteString("/S")
+	r.writeSection(&b, 256)
+	if r.C > 2 {
+		b.WriteString("/C" + strconv.Itoa(r.C))
+	}
+	return b.String()
+}
+
+// writeSection writes the configurations allowed by the entries of the table from offset
+func (r Isotropic) writeSection(b *strings.Builder, offset int) {
+	for count := 0; count <= 8; count++ {
+		letters := henselLetters[minInt(count, 8-count)]
+		if letters == "" {
+			if r.next[offset+int(fullMask(count))] {
+				b.WriteString(strconv.Itoa(count))
+			}
+			continue
+		}
+		var allowed, missing string
+		for _, l := range []byte(letters) {
+			if r.allows(offset, configuration{count, l}) {
+				allowed += string(l)
+			} else {
+				missing += string(l)
+			}
+		}
+		switch {
+		case allowed == "":
+		case missing == "":
+			b.WriteString(strconv.Itoa(count))
+		case len(missing) < len(allowed):
+			b.WriteString(strconv.Itoa(count) + "-" + missing)
+		default:
+			b.WriteString(strconv.Itoa(count) + allowed)
+		}
+	}
+}
+
+// allows reports whether the entries of the table from offset allow a configuration
+func (r Isotropic) allows(offset int, c configuration) bool {
+	for mask, class := range henselClass {
+		if class == c {
+			return r.next[offset+mask]
+		}
+	}
+	return false
+}
@@ -0,0 +1,162 @@
+package rule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// LargerThanLife is a Generations rule with a neighbourhood of any radius, where a cell is born or survives when
+// the number of alive cells in its neighbourhood falls within a range
+type LargerThanLife struct {
+	R             int           // radius of the neighbourhood
+	C             int           // number of states
+	Middle        bool          // whether the cell itself is counted
+	Birth         [2]int        // a dead cell is born when Birth[0] <= alive <= Birth[1]
+	Survival      [2]int        // an alive cell survives when Survival[0] <= alive <= Survival[1]
+	Neighbourhood Neighbourhood // Moore, VonNeumann or Hexagonal
+}
+
+// parseLargerThanLife parses the notation used by Golly such as R5,C0,M1,S34..58,B34..45,NM. C0 and C1 both
+// mean two states, and the neighbourhood is NM, NN or NH, defaulting to Moore when N is left out
+func parseLargerThanLife(s string) (Rule, error) {
+	l := LargerThanLife{C: 2, Neighbourhood: Moore}
+	seen := map[byte]bool{}
+	for _, part := range strings.Split(strings.ToUpper(s), ",") {
+		if part == "" {
+			return nil, fmt.Errorf("rule %q has an empty section", s)
+		}
+		letter := part[0]
+		if seen[letter] {
+			return nil, fmt.Errorf("rule %q has more than one %c section", s, letter)
+		}
+		seen[letter] = true
+		var err error
+		switch letter {
+		case 'R':
+			l.R, err = strconv.Atoi(part[1:])
+		case 'C':
+			l.C, err = strconv.Atoi(part[1:])
+			if l.C < 2 {
+				l.C = 2
+			}
+		case 'M':
+			l.Middle = part[1:] == "1"
+			if part[1:] != "0" && part[1:] != "1" {
+				err = fmt.Errorf("rule %q should have M0 or M1", s)
+			}
+		case 'S':
+			l.Survival, err = parseRange(part[1:])
+		case 'B':
+			l.Birth, err = parseRange(part[1:])
+		case 'N':
+			l.Neighbourhood, err = parseNeighbourhood(part[1:])
+			if l.Neighbourhood == Weighted {
+				err = fmt.Errorf("rule %q cannot have a weighted neighbourhood", s)
+			}
+		default:
+			err = fmt.Errorf("rule %q has an unknown section %q", s, part)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !seen['R'] || !seen['S'] || !seen['B'] {
+		return nil, fmt.Errorf("rule %q needs R, S and B sections", s)
+	}
+	if l.R < 1 || l.R > MaxRadius {
+		return nil, fmt.Errorf("rule %q must have a radius between 1 and %d", s, MaxRadius)
+	}
+	if l.C > 256 {
+		return nil, fmt.Errorf("rule %q must have between 2 and 256 states", s)
+	}
+	return l, nil
+}
+
+// parseRange parses a range of neighbour counts such as 34..58, where a single number is a range of one count
+func parseRange(s string) ([2]int, error) {
+	bounds := strings.SplitN(s, "..", 2)
+	if len(bounds) == 1 {
+		bounds = append(bounds, bounds[0])
+	}
+	var r [2]int
+	for i, bound := range bounds {
+		n, err := strconv.Atoi(bound)
+		if err != nil || n < 0 {
+			return r, fmt.Errorf("neighbour count range %q should be two counts such as 34..58", s)
+		}
+		r[i] = n
+	}
+	return r, nil
+}
+
+func (l LargerThanLife) States() int {
+	return l.C
+}
+
+func (l LargerThanLife) Radius() int {
+	return l.R
+}
+
+// Next applies the rule to the cell x, y by reading every cell of its neighbourhood. Workers use Count and
+// NextCount instead, which are much faster for large radii
+func (l LargerThanLife) Next(grid Grid, x, y int) uint8 {
+	alive := 0
+	for dy := -l.R; dy <= l.R; dy++ {
+		from, to := l.Neighbourhood.span(dy, l.R)
+		for dx := from; dx <= to; dx++ {
+			if grid.State(x+dx, y+dy) == 1 {
+				alive++
+			}
+		}
+	}
+	return l.NextCount(grid.State(x, y), alive)
+}
+
+// Count returns the number of alive cells in the neighbourhood of x, y including the cell itself. The Moore
+// neighbourhood is a single rectangle of the table, the others are one rectangle per row
+func (l LargerThanLife) Count(t *Table, x, y int) int {
+	if l.Neighbourhood == Moore {
+		return t.Sum(x-l.R, y-l.R, x+l.R+1, y+l.R+1)
+	}
+	alive := 0
+	for dy := -l.R; dy <= l.R; dy++ {
+		from, to := l.Neighbourhood.span(dy, l.R)
+		alive += t.Sum(x+from, y+dy, x+to+1, y+dy+1)
+	}
+	return alive
+}
+
+// NextCount returns the next state of a cell given the number of alive cells in its neighbourhood, including itself
+func (l LargerThanLife) NextCount(state uint8, alive int) uint8 {
+	if state > 1 { // dying cells carry on dying regardless of their neighbours
+		return uint8((int(state) + 1) % l.C)
+	}
+	if state == 1 && !l.Middle {
+		alive--
+	}
+	if state == 0 {
+		if alive >= l.Birth[0] && alive <= l.Birth[1] {
+			return 1
+		}
+		return 0
+	}
+	if alive >= l.Survival[0] && alive <= l.Survival[1] {
+		return 1
+	}
+	return uint8(2 % l.C)
+}
+
+// String returns the rule in the notation used by Golly
+func (l LargerThanLife) String() string {
+	middle := 0
+	if l.Middle {
+		middle = 1
+	}
+	c := l.C
+	if c == 2 {
+		c = 0
+	}
+	return fmt.Sprintf("R%d,C%d,M%d,S%d..%d,B%d..%d,N%s", l.R, c, middle,
+		l.Survival[0], l.Survival[1], l.Birth[0], l.Birth[1], l.Neighbourhood.letter())
+}
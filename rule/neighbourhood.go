@@ -0,0 +1,85 @@
+package rule
+
+import (
+	"fmt"
+	"math"
+)
+
+// Neighbourhood is the shape of the cells around a cell that a rule counts
+type Neighbourhood int
+
+const (
+	Moore      Neighbourhood = iota // the square of cells within the radius
+	VonNeumann                      // the diamond of cells within the radius, measured by Manhattan distance
+	// Hexagonal treats each row as offset half a cell from the one above, as Golly does, so the neighbours of a
+	// cell are its Moore neighbours without the top right and bottom left
+	Hexagonal
+	Weighted // a mask giving each cell around a cell its own weight, only for B/S/C rules
+)
+
+// parseNeighbourhood parses M, N or V, H, or W followed by a mask, returning only the shape of the neighbourhood
+func parseNeighbourhood(s string) (Neighbourhood, error) {
+	switch {
+	case s == "M":
+		return Moore, nil
+	case s == "N" || s == "V":
+		return VonNeumann, nil
+	case s == "H":
+		return Hexagonal, nil
+	case len(s) > 0 && s[0] == 'W':
+		return Weighted, nil
+	}
+	return Moore, fmt.Errorf("unknown neighbourhood %q, expected M, N, V, H or W followed by weights", s)
+}
+
+// parseWeights parses a square mask of single digit weights written row by row, such as 111101111 for the
+// Moore neighbourhood. The side of the mask must be odd, and the centre weight counts the cell itself
+func parseWeights(s string) ([]int, error) {
+	side := int(math.Sqrt(float64(len(s))))
+	if side*side != len(s) || side%2 == 0 || side < 3 || side > 2*MaxRadius+1 {
+		return nil, fmt.Errorf("weights %q should be an odd square of digits such as 111101111", s)
+	}
+	weights := make([]int, len(s))
+	for i, r := range s {
+		if r < '0' || r > '9' {
+			return nil, fmt.Errorf("weights %q should only be digits", s)
+		}
+		weights[i] = int(r - '0')
+	}
+	return weights, nil
+}
+
+// span returns the first and last columns, relative to the cell, of row dy of a neighbourhood with radius r
+func (n Neighbourhood) span(dy, r int) (from, to int) {
+	switch n {
+	case VonNeumann:
+		if dy < 0 {
+			dy = -dy
+		}
+		return dy - r, r - dy
+	case Hexagonal:
+		from, to = -r, r
+		if dy > 0 {
+			from = dy - r
+		} else {
+			to = dy + r
+		}
+		return
+	default:
+		return -r, r
+	}
+}
+
+// letter is the letter Larger than Life notation uses for the neighbourhood
+func (n Neighbourhood) letter() string {
+	switch n {
+	case VonNeumann:
+		return "N"
+	case Hexagonal:
+		return "H"
+	case Weighted:
+		return "W"
+	default:
+		return "M"
+	}
+}
@@ -0,0 +1,108 @@
+// Package rule parses rule strings and applies them to cells, for the rules the workers can run other than the
+// binary B3/S23 fast path.
+package rule
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Life is the rule string of Conway's Game of Life, which the workers run without going through this package
+const Life = "B3/S23"
+
+// MaxRadius is the largest neighbourhood radius a rule may have, which is also the most ghost rows a strip needs
+const MaxRadius = 32
+
+// Grid is the part of the world that a Rule reads from. The grid deals with the boundary, so a rule may read
+// cells just beyond the edges of the world
+type Grid interface {
+	State(x, y int) uint8
+}
+
+// Rule is a cellular automaton that the workers can run
+type Rule interface {
+	fmt.Stringer
+	// States is the number of states a cell can be in, 2 for binary rules. State 0 is always dead
+	States() int
+	// Radius is how far from a cell the rule reads, which is the number of ghost rows and columns a strip needs
+	Radius() int
+	// Next returns the state of the cell x, y on the next turn
+	Next(g Grid, x, y int) uint8
+}
+
+// Totalistic is implemented by rules whose next state only depends on the state of a cell and how many alive cells
+// are in its neighbourhood, so that workers can count the neighbours from a summed-area table
+type Totalistic interface {
+	Rule
+	// Count returns the number of alive cells in the neighbourhood of x, y, including the cell itself
+	Count(t *Table, x, y int) int
+	// NextCount returns the next state of a cell from its state and Count
+	NextCount(state uint8, alive int) uint8
+}
+
+// aliases are other names that rules are known by
+var aliases = map[string]string{
+	"life":         Life,
+	"conway":       Life,
+	"highlife":     "B36/S23",
+	"seeds":        "B2/S",
+	"daynight":     "B3678/S34678",
+	"briansbrain":  "B2/S/C3",
+	"brians-brain": "B2/S/C3",
+	"starwars":     "B2/S345/C4",
+	"star-wars":    "B2/S345/C4",
+	"tlife":        "B3/S2-i34q",
+	"bosco":        "R5,C0,M1,S34..58,B34..45,NM",
+	"majority":     "R4,C0,M1,S41..81,B41..81,NM",
+}
+
+// Parse returns the Rule described by a rule string, in B/S/C notation, Hensel notation such as B2-a/S12, or Larger
+// than Life notation such as R5,C0,M1,S34..58,B34..45,NM, or the name of a Fixed automaton. An empty string is Life
+func Parse(s string) (Rule, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		s = Life
+	}
+	if alias, ok := aliases[strings.ToLower(s)]; ok {
+		s = alias
+	}
+	if f, ok := fixed[strings.ToLower(s)]; ok {
+		return f, nil
+	}
+	if strings.HasPrefix(strings.ToUpper(s), "R") {
+		return parseLargerThanLife(s)
+	}
+	if isHensel(s) {
+		return parseIsotropic(s)
+	}
+	return parseGenerations(s)
+}
+
+// Families are the kinds of rule that Parse understands. Peers tell each other which of them they can run, as new
+// kinds of rule are added
+var Families = []string{"life", "generations", "isotropic", "ltl", "fixed"}
+
+// Family returns which of Families a rule belongs to
+func Family(r Rule) string {
+	if r.String() == Life {
+		return "life"
+	}
+	switch r.(type) {
+	case Generations:
+		return "generations"
+	case Isotropic:
+		return "isotropic"
+	case LargerThanLife:
+		return "ltl"
+	}
+	return "fixed"
+}
+
+// IsLife reports whether a rule string describes Conway's Game of Life, so the binary fast path can be used
+func IsLife(s string) bool {
+	r, err := Parse(s)
+	if err != nil {
+		return false
+	}
+	return r.String() == Life
+}
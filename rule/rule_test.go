@@ -0,0 +1,354 @@
+package rule
+
+import (
+	"strings"
+	"testing"
+)
+
+// grid is a small world for testing rules, where cells beyond the edges are dead
+type grid [][]uint8
+
+func (g grid) State(x, y int) uint8 {
+	if y < 0 || y >= len(g) || x < 0 || x >= len(g[y]) {
+		return 0
+	}
+	return g[y][x]
+}
+
+// step applies a rule to every cell of a grid
+func step(r Rule, g grid) grid {
+	next := make(grid, len(g))
+	for y := range g {
+		next[y] = make([]uint8, len(g[y]))
+		for x := range g[y] {
+			next[y][x] = r.Next(g, x, y)
+		}
+	}
+	return next
+}
+
+// TestParse checks that the different ways of writing a rule give the same rule
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"", "B3/S23"},
+		{"life", "B3/S23"},
+		{"S23/B3", "B3/S23"},
+		{"23/3", "B3/S23"},
+		{"b36/s23", "B36/S23"},
+		{"HighLife", "B36/S23"},
+		{"briansbrain", "B2/S/C3"},
+		{"B2/S345/G4", "B2/S345/C4"},
+		{"345/2/4", "B2/S345/C4"},
+		{"B3/S23/C2", "B3/S23"},
+	}
+	for _, test := range tests {
+		r, err := Parse(test.in)
+		if err != nil {
+			t.Errorf("Parse(%q) failed: %v", test.in, err)
+			continue
+		}
+		if r.String() != test.want {
+			t.Errorf("Parse(%q) = %v, want %v", test.in, r, test.want)
+		}
+	}
+
+	for _, bad := range []string{"B3", "B9/S23", "B3/S23/C1", "B3/S23/C300", "B3/B3/S23", "X3/S23", "B3/S23/C"} {
+		if _, err := Parse(bad); err == nil {
+			t.Errorf("Parse(%q) should fail", bad)
+		}
+	}
+}
+
+// TestBriansBrain checks that alive cells always start dying and dying cells die, moving a glider along by one
+func TestBriansBrain(t *testing.T) {
+	r, _ := Parse("briansbrain")
+	g := grid{
+		{0, 0, 0, 0, 0},
+		{0, 0, 1, 1, 0},
+		{0, 0, 2, 2, 0},
+		{0, 0, 0, 0, 0},
+	}
+	want := grid{
+		{0, 0, 1, 1, 0},
+		{0, 0, 2, 2, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+	}
+	got := step(r, g)
+	for y := range want {
+		for x := range want[y] {
+			if got[y][x] != want[y][x] {
+				t.Fatalf("turn 1 of the Brian's Brain glider was %v, want %v", got, want)
+			}
+		}
+	}
+}
+
+// TestLargerThanLife checks parsing and that counting from a summed-area table matches reading every neighbour
+func TestLargerThanLife(t *testing.T) {
+	bosco, err := Parse("Bosco")
+	if err != nil || bosco.String() != "R5,C0,M1,S34..58,B34..45,NM" || bosco.Radius() != 5 {
+		t.Fatalf("Parse(Bosco) = %v, %v", bosco, err)
+	}
+	for _, bad := range []string{"R0,C0,M1,S34..58,B34..45", "R5,C0,M2,S34..58,B34..45", "R5,S34..58", "R5,C0,M1,S34..,B34..45", "R5,C0,M1,S34..58,B34..45,NX"} {
+		if _, err := Parse(bad); err == nil {
+			t.Errorf("Parse(%q) should fail", bad)
+		}
+	}
+
+	g := make(grid, 30)
+	for y := range g {
+		g[y] = make([]uint8, 30)
+		for x := range g[y] {
+			g[y][x] = uint8((x*7 + y*13 + x*y) % 3)
+		}
+	}
+	for _, s := range []string{"R3,C0,M1,S8..20,B10..14,NM", "R3,C3,M0,S5..9,B4..6,NN"} {
+		r, _ := Parse(s)
+		l := r.(Totalistic)
+		table := NewTable(g, -3, -3, 36, 36)
+		for y := range g {
+			for x := range g[y] {
+				if got, want := l.NextCount(g.State(x, y), l.Count(table, x, y)), r.Next(g, x, y); got != want {
+					t.Fatalf("%v at (%d, %d) gave %d from the table, want %d", r, x, y, got, want)
+				}
+			}
+		}
+	}
+}
+
+// TestNeighbourhoods checks the neighbourhood suffixes and sections, and which cells each neighbourhood counts
+func TestNeighbourhoods(t *testing.T) {
+	tests := []struct {
+		in, want string
+		counted  [3][3]bool // whether a cell at each offset is counted as a neighbour of the centre
+	}{
+		{"B1/S", "B1/S", [3][3]bool{{true, true, true}, {true, false, true}, {true, true, true}}},
+		{"B1/SV", "B1/SV", [3][3]bool{{false, true, false}, {true, false, true}, {false, true, false}}},
+		{"B1/S/NN", "B1/SV", [3][3]bool{{false, true, false}, {true, false, true}, {false, true, false}}},
+		{"B1/SH", "B1/SH", [3][3]bool{{true, true, false}, {true, false, true}, {false, true, true}}},
+		{"1/1/3H", "B1/S1/C3H", [3][3]bool{{true, true, false}, {true, false, true}, {false, true, true}}},
+		{"B1/S/NW101000101", "B1/S/NW101000101", [3][3]bool{{true, false, true}, {false, false, false}, {true, false, true}}},
+	}
+	for _, test := range tests {
+		r, err := Parse(test.in)
+		if err != nil {
+			t.Errorf("Parse(%q) failed: %v", test.in, err)
+			continue
+		}
+		if r.String() != test.want {
+			t.Errorf("Parse(%q) = %v, want %v", test.in, r, test.want)
+		}
+		for dy := -1; dy <= 1; dy++ {
+			for dx := -1; dx <= 1; dx++ {
+				if dx == 0 && dy == 0 {
+					continue
+				}
+				g := grid{{0, 0, 0}, {0, 0, 0}, {0, 0, 0}}
+				g[1+dy][1+dx] = 1
+				if born := r.Next(g, 1, 1) == 1; born != test.counted[1+dy][1+dx] {
+					t.Errorf("%v: a cell at (%d, %d) counted is %v, want %v", r, dx, dy, born, test.counted[1+dy][1+dx])
+				}
+			}
+		}
+	}
+
+	weighted, _ := Parse("B4/S/NW0000000000002000000000000")
+	if weighted.Radius() != 2 {
+		t.Errorf("%v has radius %d, want 2", weighted, weighted.Radius())
+	}
+	for _, bad := range []string{"B3/S23/NX", "B3/S23/NW1111", "B3/S23/NW11110111a"} {
+		if _, err := Parse(bad); err == nil {
+			t.Errorf("Parse(%q) should fail", bad)
+		}
+	}
+}
+
+// TestHenselClasses checks that every neighbourhood has the number of neighbours it is classed with, and that each
+// number of neighbours has the expected number of configurations
+func TestHenselClasses(t *testing.T) {
+	classes := map[configuration]bool{}
+	perCount := make([]int, 9)
+	for mask, class := range henselClass {
+		alive := 0
+		for i := uint(0); i < 8; i++ {
+			if mask&(1<<i) != 0 {
+				alive++
+			}
+		}
+		if alive != class.count {
+			t.Fatalf("neighbourhood %08b with %d neighbours is classed as %d%c", mask, alive, class.count, class.letter)
+		}
+		if !classes[class] {
+			classes[class] = true
+			perCount[class.count]++
+		}
+	}
+	want := []int{1, 2, 6, 10, 13, 10, 6, 2, 1}
+	for n := range want {
+		if perCount[n] != want[n] {
+			t.Errorf("%d neighbours have %d configurations, want %d", n, perCount[n], want[n])
+		}
+	}
+}
+
+// TestIsotropic checks parsing Hensel notation and that it agrees with B/S notation when every letter is allowed
+func TestIsotropic(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"B2-a/S12", "B2-a/S12"},
+		{"tlife", "B3/S2-i34q"},
+		{"B3cekainyqjr/S2cekain3cekainyqjr", "B3/S23"},
+		{"B2ce3-jqr/S1c/C3", "B2ce3-qjr/S1c/C3"},
+		{"B4ceaiknqjr/S", "B4-ytwz/S"},
+		{"B5-i/S7e", "B5-i/S7e"},
+	}
+	for _, test := range tests {
+		r, err := Parse(test.in)
+		if err != nil {
+			t.Errorf("Parse(%q) failed: %v", test.in, err)
+			continue
+		}
+		if r.String() != test.want {
+			t.Errorf("Parse(%q) = %v, want %v", test.in, r, test.want)
+		}
+	}
+	if !IsLife("B3cekainyqjr/S2cekain3cekainyqjr") {
+		t.Error("Life in Hensel notation should use the Life fast path")
+	}
+	for _, bad := range []string{"B2x/S23", "B1k/S23", "B2-/S23", "B3a", "B9a/S"} {
+		if _, err := Parse(bad); err == nil {
+			t.Errorf("Parse(%q) should fail", bad)
+		}
+	}
+
+	g := make(grid, 12)
+	for y := range g {
+		g[y] = make([]uint8, 12)
+		for x := range g[y] {
+			g[y][x] = uint8((x*x + y*5 + x*y*3) % 3)
+		}
+	}
+	isotropic, _ := Parse("B2cekain3cekainyqjr6cekain/S1ce5cekainyqjr8/C3")
+	generations, _ := Parse("B236/S158/C3")
+	for y := range g {
+		for x := range g[y] {
+			if got, want := isotropic.Next(g, x, y), generations.Next(g, x, y); got != want {
+				t.Fatalf("%v at (%d, %d) gave %d, want %d as %v does", isotropic, x, y, got, want, generations)
+			}
+		}
+	}
+}
+
+// TestHenselPatterns checks rules that are only born with one arrangement of neighbours on patterns worked out by hand
+func TestHenselPatterns(t *testing.T) {
+	tests := []struct {
+		rule        string
+		start, want grid
+	}{
+		// two edge neighbours at right angles are born, so a diagonal pair flips between diagonals
+		{"B2e/S", grid{{0, 0, 0, 0}, {0, 1, 0, 0}, {0, 0, 1, 0}, {0, 0, 0, 0}},
+			grid{{0, 0, 0, 0}, {0, 0, 1, 0}, {0, 1, 0, 0}, {0, 0, 0, 0}}},
+		// a knight's move apart, only the two cells that see an edge and the far corner are born
+		{"B2k/S", grid{{0, 0, 0, 0}, {0, 1, 0, 0}, {0, 0, 0, 0}, {0, 0, 1, 0}},
+			grid{{0, 0, 0, 0}, {0, 0, 0, 0}, {0, 1, 1, 0}, {0, 0, 0, 0}}},
+		// three in a row along a side, only the cells beside the middle of the row see a 3i
+		{"B3i/S", grid{{0, 0, 0, 0, 0}, {0, 1, 1, 1, 0}, {0, 0, 0, 0, 0}, {0, 0, 0, 0, 0}},
+			grid{{0, 0, 1, 0, 0}, {0, 0, 0, 0, 0}, {0, 0, 1, 0, 0}, {0, 0, 0, 0, 0}}},
+		// an L tromino survives only where the cell sees its two neighbours at right angles, as a 2e
+		{"B/S2e", grid{{0, 0, 0, 0}, {0, 1, 1, 0}, {0, 1, 0, 0}, {0, 0, 0, 0}},
+			grid{{0, 0, 0, 0}, {0, 1, 0, 0}, {0, 0, 0, 0}, {0, 0, 0, 0}}},
+	}
+	for _, test := range tests {
+		r, err := Parse(test.rule)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := step(r, test.start)
+		for y := range test.want {
+			for x := range test.want[y] {
+				if got[y][x] != test.want[y][x] {
+					t.Fatalf("%v gave %v, want %v", r, got, test.want)
+				}
+			}
+		}
+	}
+}
+
+// parseWireworld reads a Wireworld circuit drawn with . for empty, H for heads, t for tails and C for conductors
+func parseWireworld(rows ...string) grid {
+	g := make(grid, len(rows))
+	for y, row := range rows {
+		g[y] = make([]uint8, len(row))
+		for x, c := range row {
+			g[y][x] = uint8(strings.IndexRune(".HtC", c))
+		}
+	}
+	return g
+}
+
+// TestWireworldClock checks a clock made of a loop of 12 conductors with one electron and a wire leading out of it.
+// The electron goes round the loop every 12 turns, sending a head to the end of the wire each time it passes
+func TestWireworldClock(t *testing.T) {
+	r, err := Parse("WireWorld")
+	if err != nil || r.States() != 4 {
+		t.Fatalf("Parse(WireWorld) = %v, %v", r, err)
+	}
+	start := parseWireworld(
+		"..tHCCC.......",
+		".C.....CCCCCCC",
+		"..CCCCC.......",
+	)
+	g := start
+	for turn := 1; turn <= 36; turn++ {
+		g = step(r, g)
+		if head := g[1][13] == 1; head != (turn%12 == 10) {
+			t.Fatalf("turn %d: the end of the wire being a head is %v", turn, head)
+		}
+	}
+	for y := range start {
+		for x := range start[y] {
+			if g[y][x] != start[y][x] {
+				t.Fatalf("the clock is not back where it started after 36 turns, cell (%d, %d) is %d", x, y, g[y][x])
+			}
+		}
+	}
+}
+
+// TestColours checks that every state of a rule is read back from the grey level it is written as
+func TestColours(t *testing.T) {
+	for _, s := range []string{"wireworld", "B2/S345/C4", "B3/S23", "R2,C7,M1,S3..6,B4..5,NM"} {
+		r, _ := Parse(s)
+		for state := 0; state < r.States(); state++ {
+			if got := FromGrey(r, Grey(r, uint8(state))); got != uint8(state) {
+				t.Errorf("%v: state %d is written as %d and read back as %d", r, state, Grey(r, uint8(state)), got)
+			}
+		}
+	}
+}
+
+// TestFamily checks that rules are put in the family that parses them
+func TestFamily(t *testing.T) {
+	tests := map[string]string{
+		"life":        "life",
+		"B3/S23/C2":   "life",
+		"highlife":    "generations",
+		"briansbrain": "generations",
+		"tlife":       "isotropic",
+		"bosco":       "ltl",
+		"wireworld":   "fixed",
+	}
+	for in, want := range tests {
+		r, err := Parse(in)
+		if err != nil {
+			t.Errorf("Parse(%q) failed: %v", in, err)
+			continue
+		}
+		if got := Family(r); got != want {
+			t.Errorf("Family(%v) = %s, want %s", r, got, want)
+		}
+	}
+}
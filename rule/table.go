@@ -0,0 +1,32 @@
+package rule
+
+// Table is a summed-area table of the alive cells in a rectangle of a grid, so the number of alive cells in any
+// rectangle inside it can be found with four lookups however large the rectangle is
+type Table struct {
+	x0, y0, width int
+	sums          []int32 // sums[(y+1)*(width+1)+x+1] is the number of alive cells above and to the left of x, y
+}
+
+// NewTable builds the summed-area table of the width by height rectangle of a grid with its top left at x0, y0
+func NewTable(g Grid, x0, y0, width, height int) *Table {
+	t := &Table{x0: x0, y0: y0, width: width, sums: make([]int32, (width+1)*(height+1))}
+	stride := width + 1
+	for y := 0; y < height; y++ {
+		var row int32
+		for x := 0; x < width; x++ {
+			if g.State(x0+x, y0+y) == 1 {
+				row++
+			}
+			t.sums[(y+1)*stride+x+1] = t.sums[y*stride+x+1] + row
+		}
+	}
+	return t
+}
+
+// Sum returns the number of alive cells from x0, y0 (inclusive) to x1, y1 (exclusive)
+func (t *Table) Sum(x0, y0, x1, y1 int) int {
+	stride := t.width + 1
+	x0, x1 = x0-t.x0, x1-t.x0
+	y0, y1 = y0-t.y0, y1-t.y0
+	return int(t.sums[y1*stride+x1] - t.sums[y0*stride+x1] - t.sums[y1*stride+x0] + t.sums[y0*stride+x0])
+}
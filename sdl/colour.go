@@ -0,0 +1,83 @@
+package sdl
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"strings"
+)
+
+// Ways of colouring the cells of the window
+const (
+	ColourPlain  = "plain"  // alive cells in the alive colour
+	ColourAge    = "age"    // alive cells by how many turns they have been alive, through the palette
+	ColourChange = "change" // cells born since the last frame in green and cells that died since then in red
+)
+
+// Colourings lists the ways of colouring cells, in the order the c key cycles through them
+var Colourings = []string{ColourPlain, ColourAge, ColourChange}
+
+// palettes are the gradients used to colour cells by age, from the youngest to the oldest
+var palettes = map[string][]color.RGBA{
+	"grey":    {{0x40, 0x40, 0x40, 0xFF}, {0xFF, 0xFF, 0xFF, 0xFF}},
+	"heat":    {{0xFF, 0xFF, 0xFF, 0xFF}, {0xFF, 0xE0, 0x40, 0xFF}, {0xE0, 0x30, 0x10, 0xFF}, {0x60, 0x00, 0x20, 0xFF}},
+	"viridis": {{0xFD, 0xE7, 0x25, 0xFF}, {0x5E, 0xC9, 0x62, 0xFF}, {0x21, 0x91, 0x8C, 0xFF}, {0x3B, 0x52, 0x8B, 0xFF}, {0x44, 0x01, 0x54, 0xFF}},
+	"ocean":   {{0xE0, 0xFF, 0xFF, 0xFF}, {0x40, 0xC0, 0xE0, 0xFF}, {0x10, 0x50, 0xA0, 0xFF}, {0x08, 0x18, 0x50, 0xFF}},
+}
+
+// Palettes lists the palettes, in the order the t key cycles through them
+var Palettes = []string{"heat", "viridis", "ocean", "grey"}
+
+// Colours of cells that were born or died since the last frame
+var (
+	bornColour = color.RGBA{R: 0x40, G: 0xFF, B: 0x40, A: 0xFF}
+	diedColour = color.RGBA{R: 0xC0, G: 0x20, B: 0x20, A: 0xFF}
+)
+
+// maxAge is the age in turns from which cells are drawn in the last colour of the palette
+const maxAge = 1000
+
+// CheckColours returns an error if the colouring or palette is not one the window knows
+func CheckColours(colouring, palette string) error {
+	if indexOf(Colourings, colouring) < 0 {
+		return fmt.Errorf("unknown colouring %q, expected one of %s", colouring, strings.Join(Colourings, ", "))
+	}
+	if indexOf(Palettes, palette) < 0 {
+		return fmt.Errorf("unknown palette %q, expected one of %s", palette, strings.Join(Palettes, ", "))
+	}
+	return nil
+}
+
+// indexOf returns the position of a name in a list, or -1 if it is not there
+func indexOf(names []string, name string) int {
+	for i, n := range names {
+		if n == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// gradient returns the colour at t, from 0 to 1, along the stops of a palette
+func gradient(stops []color.RGBA, t float64) color.RGBA {
+	t = math.Max(0, math.Min(1, t)) * float64(len(stops)-1)
+	i := int(t)
+	if i >= len(stops)-1 {
+		return stops[len(stops)-1]
+	}
+	f := t - float64(i)
+	mix := func(a, b uint8) uint8 {
+		return uint8(float64(a) + (float64(b)-float64(a))*f + 0.5)
+	}
+	a, b := stops[i], stops[i+1]
+	return color.RGBA{R: mix(a.R, b.R), G: mix(a.G, b.G), B: mix(a.B, b.B), A: 0xFF}
+}
+
+// ageColour returns the colour of a cell that has been alive for age turns, on a log scale so that the young
+// cells around activity stand apart while still lifes settle into the last colour
+func ageColour(stops []color.RGBA, age int) color.RGBA {
+	if age < 0 {
+		age = 0
+	}
+	return gradient(stops, math.Log1p(float64(age))/math.Log1p(maxAge))
+}
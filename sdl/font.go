@@ -0,0 +1,76 @@
+package sdl
+
+import (
+	"image/color"
+	"strings"
+)
+
+// glyphs is a 3 by 5 pixel font for the text drawn over the world, each row is three bits with the left pixel
+// highest. Letters are drawn in capitals, and characters that are not here are left blank
+var glyphs = map[rune][5]uint8{
+	'0': {7, 5, 5, 5, 7},
+	'1': {2, 6, 2, 2, 7},
+	'2': {7, 1, 7, 4, 7},
+	'3': {7, 1, 7, 1, 7},
+	'4': {5, 5, 7, 1, 1},
+	'5': {7, 4, 7, 1, 7},
+	'6': {7, 4, 7, 5, 7},
+	'7': {7, 1, 1, 1, 1},
+	'8': {7, 5, 7, 5, 7},
+	'9': {7, 5, 7, 1, 7},
+	'A': {2, 5, 7, 5, 5},
+	'B': {6, 5, 6, 5, 6},
+	'C': {3, 4, 4, 4, 3},
+	'D': {6, 5, 5, 5, 6},
+	'E': {7, 4, 6, 4, 7},
+	'F': {7, 4, 6, 4, 4},
+	'G': {3, 4, 5, 5, 3},
+	'H': {5, 5, 7, 5, 5},
+	'I': {7, 2, 2, 2, 7},
+	'J': {1, 1, 1, 5, 2},
+	'K': {5, 5, 6, 5, 5},
+	'L': {4, 4, 4, 4, 7},
+	'M': {5, 7, 7, 5, 5},
+	'N': {6, 5, 5, 5, 5},
+	'O': {2, 5, 5, 5, 2},
+	'P': {6, 5, 6, 4, 4},
+	'Q': {2, 5, 5, 6, 3},
+	'R': {6, 5, 6, 5, 5},
+	'S': {3, 4, 2, 1, 6},
+	'T': {7, 2, 2, 2, 2},
+	'U': {5, 5, 5, 5, 7},
+	'V': {5, 5, 5, 5, 2},
+	'W': {5, 5, 7, 7, 5},
+	'X': {5, 5, 2, 5, 5},
+	'Y': {5, 5, 2, 2, 2},
+	'Z': {7, 1, 2, 4, 7},
+	'+': {0, 2, 7, 2, 0},
+	'-': {0, 0, 7, 0, 0},
+	'_': {0, 0, 0, 0, 7},
+	'.': {0, 0, 0, 0, 2},
+	':': {0, 2, 0, 2, 0},
+	'/': {1, 1, 2, 4, 4},
+}
+
+// Size of the text in pixels of the window
+const (
+	legendScale = 2               // size of each pixel of the font
+	glyphWidth  = 4 * legendScale // width of a character, with a pixel of space after it
+	glyphHeight = 5 * legendScale
+)
+
+// text draws a label with its top left at x, y, returning the x just after it
+func (w *Window) text(x, y int, label string, c color.RGBA) int {
+	for _, r := range strings.ToUpper(label) {
+		glyph := glyphs[r]
+		for row, bits := range glyph {
+			for column := 0; column < 3; column++ {
+				if bits&(4>>uint(column)) != 0 {
+					w.fill(x+column*legendScale, y+row*legendScale, legendScale, legendScale, c)
+				}
+			}
+		}
+		x += glyphWidth
+	}
+	return x
+}
@@ -0,0 +1,86 @@
+package sdl
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"uk.ac.bris.cs/gameoflife/gol"
+)
+
+// rateInterval is how long turns are counted for before the turns per second are worked out again
+const rateInterval = time.Second
+
+// hud is the state shown in the overlay in the top left of the window, kept up to date from the events
+type hud struct {
+	shown      bool
+	turn       int
+	population int // -1 until the first count of alive cells
+	rate       float64
+	state      gol.State
+	rule       string
+	workers    int // -1 until the distributor has connected, 0 when running locally
+	sampleTurn int // the turn and time the rate is being measured from
+	sampleTime time.Time
+}
+
+// newHUD returns a hidden overlay for a world running rule
+func newHUD(rule string) hud {
+	return hud{population: -1, state: gol.Executing, rule: rule, workers: -1, sampleTime: time.Now()}
+}
+
+// update records that turn has been completed, working out the turns per second once rateInterval has passed
+func (h *hud) update(turn int, now time.Time) {
+	h.turn = turn
+	if elapsed := now.Sub(h.sampleTime); elapsed >= rateInterval {
+		h.rate = float64(turn-h.sampleTurn) / elapsed.Seconds()
+		h.sampleTurn, h.sampleTime = turn, now
+	}
+}
+
+// setState records that execution was paused or resumed, measuring the rate afresh from then
+func (h *hud) setState(state gol.State, turn int, now time.Time) {
+	h.state, h.turn = state, turn
+	h.rate, h.sampleTurn, h.sampleTime = 0, turn, now
+}
+
+// lines returns the text of the overlay
+func (h *hud) lines() []string {
+	population, workers := "-", "-"
+	if h.population >= 0 {
+		population = strconv.Itoa(h.population)
+	}
+	switch {
+	case h.workers == 0:
+		workers = "local"
+	case h.workers > 0:
+		workers = strconv.Itoa(h.workers)
+	}
+	return []string{
+		"turn " + strconv.Itoa(h.turn),
+		"alive " + population,
+		fmt.Sprintf("rate %.1f/s", h.rate),
+		h.state.String(),
+		"rule " + h.rule,
+		"workers " + workers,
+	}
+}
+
+// drawHUD draws the overlay in the top left of the window when it is shown
+func (w *Window) drawHUD() {
+	if !w.hud.shown {
+		return
+	}
+	lines := w.hud.lines()
+	longest := 0
+	for _, line := range lines {
+		if len(line) > longest {
+			longest = len(line)
+		}
+	}
+	lineHeight := glyphHeight + legendMargin/2
+	w.fill(legendMargin, legendMargin, longest*glyphWidth+2*legendMargin, len(lines)*lineHeight+2*legendMargin-legendMargin/2, legendBackground)
+	for i, line := range lines {
+		w.text(2*legendMargin, 2*legendMargin+i*lineHeight, line, textColour)
+	}
+}
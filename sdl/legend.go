@@ -0,0 +1,62 @@
+package sdl
+
+import (
+	"image/color"
+	"strconv"
+)
+
+// Layout of the legend in the bottom left of the window, in pixels
+const (
+	legendMargin = 8
+	barWidth     = 160
+	barHeight    = 10
+	swatchSize   = 10
+)
+
+// Colours of the boxes drawn over the world and the text in them
+var (
+	legendBackground = color.RGBA{R: 0x10, G: 0x10, B: 0x10, A: 0xFF}
+	textColour       = color.RGBA{R: 0xFF, G: 0xFF, B: 0xFF, A: 0xFF}
+)
+
+// fill draws a rectangle of the window in one colour, clipped to the window
+func (w *Window) fill(x0, y0, width, height int, c color.RGBA) {
+	for y := y0; y < y0+height; y++ {
+		for x := x0; x < x0+width; x++ {
+			if x >= 0 && y >= 0 && x < windowWidth && y < windowHeight {
+				w.put(x, y, c)
+			}
+		}
+	}
+}
+
+// drawLegend draws a key to the colours in the bottom left of the window. Ages are shown as the palette from 0 to
+// maxAge turns, and changes as swatches for born, alive and died cells
+func (w *Window) drawLegend() {
+	switch w.colouring {
+	case ColourAge:
+		height := barHeight + glyphHeight + 3*legendMargin
+		top := windowHeight - legendMargin - height
+		w.fill(legendMargin, top, barWidth+2*legendMargin, height, legendBackground)
+		for i := 0; i < barWidth; i++ {
+			age := int(float64(i) / float64(barWidth-1) * maxAge)
+			w.fill(2*legendMargin+i, top+legendMargin, 1, barHeight, ageColour(palettes[w.palette], age))
+		}
+		labelY := top + 2*legendMargin + barHeight
+		w.text(2*legendMargin, labelY, "0", textColour)
+		maxLabel := strconv.Itoa(maxAge) + "+"
+		w.text(2*legendMargin+barWidth-len(maxLabel)*glyphWidth, labelY, maxLabel, textColour)
+	case ColourChange:
+		height := swatchSize + 2*legendMargin
+		top := windowHeight - legendMargin - height
+		w.fill(legendMargin, top, legendMargin+3*(swatchSize+legendMargin/2+glyphWidth+legendMargin), height, legendBackground)
+		x := 2 * legendMargin
+		for _, key := range []struct {
+			label  string
+			colour color.RGBA
+		}{{"B", bornColour}, {"A", w.alive}, {"D", diedColour}} {
+			w.fill(x, top+legendMargin, swatchSize, swatchSize, key.colour)
+			x = w.text(x+swatchSize+legendMargin/2, top+legendMargin, key.label, textColour) + legendMargin
+		}
+	}
+}
@@ -2,12 +2,98 @@ package sdl
 
 import (
 	"fmt"
+	"io/ioutil"
+	"math"
+	"time"
+
 	"github.com/veandco/go-sdl2/sdl"
 	"uk.ac.bris.cs/gameoflife/gol"
+	"uk.ac.bris.cs/gameoflife/rle"
+	"uk.ac.bris.cs/gameoflife/rule"
+	"uk.ac.bris.cs/gameoflife/util"
 )
 
-func Run(p gol.Params, events <-chan gol.Event, keyPresses chan<- rune) {
+// loadPattern reads the RLE file that can be dropped into the world, returning nil if there is none or it is broken
+func loadPattern(path string) (text string, pattern *rle.Pattern) {
+	if path == "" {
+		return "", nil
+	}
+	data, err := ioutil.ReadFile(path)
+	if err == nil {
+		pattern, err = rle.Parse(string(data))
+	}
+	if err != nil {
+		fmt.Println(err)
+		return "", nil
+	}
+	return string(data), pattern
+}
+
+// line returns the cells on a straight line from one cell to another, not including from, so that a fast drag
+// draws every cell it passes over
+func line(from, to util.Cell) []util.Cell {
+	dx, dy := to.X-from.X, to.Y-from.Y
+	steps := dx
+	if steps < 0 {
+		steps = -steps
+	}
+	if dy > steps || -dy > steps {
+		steps = dy
+		if steps < 0 {
+			steps = -steps
+		}
+	}
+	cells := make([]util.Cell, 0, steps)
+	for i := 1; i <= steps; i++ {
+		t := float64(i) / float64(steps)
+		cells = append(cells, util.Cell{X: from.X + int(math.Round(t*float64(dx))), Y: from.Y + int(math.Round(t*float64(dy)))})
+	}
+	return cells
+}
+
+// zoomStep is how much one notch of the mouse wheel zooms in or out
+const zoomStep = 1.25
+
+// Run shows the world in a window until the final turn. Key presses are sent to keyPresses. Clicking or dragging
+// with the left mouse button sends edits that draw cells, or erase them if the first cell was alive, and the right
+// mouse button drops the pattern given in the params centred under the cursor. The mouse wheel zooms, dragging
+// with the middle mouse button pans and f fits the world to the window, sending the cells in view to views.
+// c cycles through the ways of colouring cells, t through the palettes and h shows or hides the overlay.
+// edits and views may be nil
+func Run(p gol.Params, events <-chan gol.Event, keyPresses chan<- rune, edits chan<- gol.Edit, views chan<- gol.Viewport) {
 	w := NewWindow(int32(p.ImageWidth), int32(p.ImageHeight))
+	if p.Colouring != "" {
+		w.SetColours(p.Colouring, p.Palette, p.Alive, p.Dead)
+	}
+	w.hud = newHUD(p.Rule)
+	if r, err := rule.Parse(p.Rule); err == nil {
+		w.hud.rule = r.String()
+	}
+	text, pattern := loadPattern(p.Pattern)
+	send := func(edit gol.Edit) {
+		if edits != nil {
+			edits <- edit
+		}
+	}
+	// moved draws the window again once the view has changed, and asks for the cells now in view
+	moved := func() {
+		w.RenderFrame()
+		if views != nil {
+			x, y, width, height := w.Viewport()
+			views <- gol.Viewport{X: x, Y: y, Width: width, Height: height}
+		}
+	}
+	// report prints an event, drawing the window again if the overlay is shown as it may have changed
+	report := func(event gol.Event) {
+		if w.hud.shown {
+			w.RenderFrame()
+		}
+		if len(event.String()) > 0 {
+			fmt.Printf("Completed Turns %-8v%v\n", event.GetCompletedTurns(), event)
+		}
+	}
+	drawing, drawAlive, panning := false, false, false
+	var last util.Cell
 
 sdlLoop:
 	for {
@@ -24,6 +110,54 @@ sdlLoop:
 					keyPresses <- 'q'
 				case sdl.K_k:
 					keyPresses <- 'k'
+				case sdl.K_f:
+					w.Fit()
+					moved()
+				case sdl.K_c:
+					w.CycleColouring()
+					w.RenderFrame()
+				case sdl.K_t:
+					w.CyclePalette()
+					w.RenderFrame()
+				case sdl.K_h:
+					w.ToggleHUD()
+					w.RenderFrame()
+				}
+			case *sdl.MouseButtonEvent:
+				cell, ok := w.CellAt(e.X, e.Y)
+				switch {
+				case e.Type == sdl.MOUSEBUTTONUP && e.Button == sdl.BUTTON_MIDDLE:
+					panning = false
+					moved()
+				case e.Type == sdl.MOUSEBUTTONUP:
+					drawing = false
+				case e.Button == sdl.BUTTON_MIDDLE:
+					panning = true
+				case !ok:
+				case e.Button == sdl.BUTTON_LEFT:
+					drawing, drawAlive, last = true, !w.Pixel(cell.X, cell.Y), cell
+					send(gol.Edit{Cell: cell, Alive: drawAlive})
+				case e.Button == sdl.BUTTON_RIGHT && pattern != nil:
+					send(gol.Edit{Cell: util.Cell{X: cell.X - pattern.Width/2, Y: cell.Y - pattern.Height/2}, Pattern: text})
+				}
+			case *sdl.MouseWheelEvent:
+				if e.Y != 0 {
+					x, y, _ := sdl.GetMouseState()
+					w.ZoomAt(math.Pow(zoomStep, float64(e.Y)), x, y)
+					moved()
+				}
+			case *sdl.MouseMotionEvent:
+				if panning {
+					// the view is only sent when the drag ends, so the broker is not asked for every step
+					w.Pan(e.XRel, e.YRel)
+					w.RenderFrame()
+				}
+				cell, ok := w.CellAt(e.X, e.Y)
+				if ok && drawing && e.State&sdl.ButtonLMask() != 0 && cell != last {
+					for _, c := range line(last, cell) {
+						send(gol.Edit{Cell: c, Alive: drawAlive})
+					}
+					last = cell
 				}
 			}
 		}
@@ -35,16 +169,26 @@ sdlLoop:
 			}
 			switch e := event.(type) {
 			case gol.CellFlipped:
-				w.FlipPixel(e.Cell.X, e.Cell.Y)
+				w.FlipCell(e.Cell.X, e.Cell.Y, e.CompletedTurns)
 			case gol.TurnComplete:
+				w.EndTurn(e.CompletedTurns)
+				w.hud.update(e.CompletedTurns, time.Now())
 				w.RenderFrame()
 			case gol.FinalTurnComplete:
 				w.Destroy()
 				break sdlLoop
+			case gol.AliveCellsCount:
+				w.hud.population = e.CellsCount
+				w.hud.update(e.CompletedTurns, time.Now())
+				report(event)
+			case gol.StateChange:
+				w.hud.setState(e.NewState, e.CompletedTurns, time.Now())
+				report(event)
+			case gol.WorkersConnected:
+				w.hud.workers = e.Workers
+				report(event)
 			default:
-				if len(event.String()) > 0 {
-					fmt.Printf("Completed Turns %-8v%v\n", event.GetCompletedTurns(), event)
-				}
+				report(event)
 			}
 		default:
 			break
@@ -2,45 +2,124 @@ package sdl
 
 import (
 	"fmt"
+	"image/color"
+	"math"
 
 	"github.com/veandco/go-sdl2/sdl"
 	"uk.ac.bris.cs/gameoflife/util"
 )
 
+// The size of the window in pixels, whatever the size of the world
+const (
+	windowWidth  = 800
+	windowHeight = 800
+)
+
+// Zoom limits in pixels per cell, and the zoom from which grid lines are drawn between the cells
+const (
+	minZoom  = 1.0 / 64
+	maxZoom  = 64
+	gridZoom = 8
+)
+
+// Colours of the grid lines and of the space around the world
+var (
+	gridColour    = color.RGBA{R: 0x30, G: 0x30, B: 0x30, A: 0xFF}
+	outsideColour = color.RGBA{R: 0x18, G: 0x18, B: 0x18, A: 0xFF}
+)
+
+// Window shows part of a world of Width by Height cells, zoomed to Zoom pixels per cell with the cell ViewX, ViewY
+// at the top left of the window
 type Window struct {
 	Width, Height int32
+	Zoom          float64
+	ViewX, ViewY  float64
 	window        *sdl.Window
 	renderer      *sdl.Renderer
 	texture       *sdl.Texture
 	pixels        []byte
+	cells         []util.BitArray // the cells that are drawn as alive
+	colouring     string          // one of Colourings
+	palette       string          // one of Palettes
+	alive, dead   color.RGBA
+	turn          int     // the turn of the latest frame
+	frameTurn     int     // the turn of the frame before, cells that flipped after it have just changed
+	flipped       []int32 // the turn each cell last flipped on, only kept when colouring by age or change
+	hud           hud
 }
 
 func filterEvent(e sdl.Event, userdata interface{}) bool {
-	return e.GetType() == sdl.KEYDOWN || e.GetType() == sdl.QUIT
+	switch e.GetType() {
+	case sdl.KEYDOWN, sdl.QUIT, sdl.MOUSEBUTTONDOWN, sdl.MOUSEBUTTONUP, sdl.MOUSEMOTION, sdl.MOUSEWHEEL:
+		return true
+	}
+	return false
 }
 
+// NewWindow opens a window for a world of width by height cells, zoomed so that all of it fits
 func NewWindow(width, height int32) *Window {
 	err := sdl.Init(sdl.INIT_EVERYTHING)
 	util.Check(err)
-	window, err := sdl.CreateWindow("GOL GUI", sdl.WINDOWPOS_CENTERED, sdl.WINDOWPOS_CENTERED, width, height, sdl.WINDOW_SHOWN)
+	window, err := sdl.CreateWindow("GOL GUI", sdl.WINDOWPOS_CENTERED, sdl.WINDOWPOS_CENTERED, windowWidth, windowHeight, sdl.WINDOW_SHOWN)
 	util.Check(err)
 	renderer, err := sdl.CreateRenderer(window, -1, sdl.WINDOW_SHOWN)
 	util.Check(err)
-	sdl.SetHint(sdl.HINT_RENDER_SCALE_QUALITY, "linear")
-	err = renderer.SetLogicalSize(width, height)
+	sdl.SetHint(sdl.HINT_RENDER_SCALE_QUALITY, "nearest")
+	err = renderer.SetLogicalSize(windowWidth, windowHeight)
 	util.Check(err)
-	texture, err := renderer.CreateTexture(sdl.PIXELFORMAT_ARGB8888, sdl.TEXTUREACCESS_STATIC, width, height)
+	texture, err := renderer.CreateTexture(sdl.PIXELFORMAT_ARGB8888, sdl.TEXTUREACCESS_STATIC, windowWidth, windowHeight)
 	util.Check(err)
 
 	sdl.SetEventFilterFunc(filterEvent, nil)
-	return &Window{
-		width,
-		height,
-		window,
-		renderer,
-		texture,
-		make([]byte, width*height*4),
+	cells := make([]util.BitArray, height)
+	for y := range cells {
+		cells[y] = util.NewBitArray(int(width+7) &^ 7)
 	}
+	w := &Window{
+		Width:    width,
+		Height:   height,
+		window:   window,
+		renderer: renderer,
+		texture:  texture,
+		pixels:   make([]byte, windowWidth*windowHeight*4),
+		cells:    cells,
+		hud:      newHUD(""),
+	}
+	w.SetColours(ColourPlain, Palettes[0], color.RGBA{}, color.RGBA{})
+	w.Fit()
+	return w
+}
+
+// SetColours chooses how cells are coloured, with alive and dead the colours of plain cells. An alive colour that is
+// not set is white. The turns cells flip on are only tracked from the first time a colouring needs them, so cells
+// alive before then are taken to have been born on that turn
+func (w *Window) SetColours(colouring, palette string, alive, dead color.RGBA) {
+	if alive == (color.RGBA{}) {
+		alive = color.RGBA{R: 0xFF, G: 0xFF, B: 0xFF}
+	}
+	alive.A, dead.A = 0xFF, 0xFF
+	w.colouring, w.palette, w.alive, w.dead = colouring, palette, alive, dead
+	if colouring != ColourPlain && w.flipped == nil {
+		w.flipped = make([]int32, int(w.Width)*int(w.Height))
+		for i := range w.flipped {
+			w.flipped[i] = int32(w.turn)
+		}
+	}
+}
+
+// ToggleHUD shows or hides the overlay with the turn, population, rate, state, rule and workers
+func (w *Window) ToggleHUD() {
+	w.hud.shown = !w.hud.shown
+}
+
+// CycleColouring moves on to the next way of colouring cells
+func (w *Window) CycleColouring() {
+	w.SetColours(Colourings[(indexOf(Colourings, w.colouring)+1)%len(Colourings)], w.palette, w.alive, w.dead)
+}
+
+// CyclePalette moves on to the next palette for colouring cells by age
+func (w *Window) CyclePalette() {
+	w.SetColours(w.colouring, Palettes[(indexOf(Palettes, w.palette)+1)%len(Palettes)], w.alive, w.dead)
 }
 
 func (w *Window) Destroy() {
@@ -53,8 +132,121 @@ func (w *Window) Destroy() {
 	sdl.Quit()
 }
 
+// Fit zooms so that the whole world fits in the window, centred
+func (w *Window) Fit() {
+	w.Zoom = math.Min(float64(windowWidth)/float64(w.Width), float64(windowHeight)/float64(w.Height))
+	w.ViewX = (float64(w.Width) - windowWidth/w.Zoom) / 2
+	w.ViewY = (float64(w.Height) - windowHeight/w.Zoom) / 2
+}
+
+// ZoomAt multiplies the zoom by factor, keeping the cell under the pixel x, y in place
+func (w *Window) ZoomAt(factor float64, x, y int32) {
+	cellX, cellY := w.ViewX+float64(x)/w.Zoom, w.ViewY+float64(y)/w.Zoom
+	w.Zoom = math.Max(minZoom, math.Min(maxZoom, w.Zoom*factor))
+	w.ViewX, w.ViewY = cellX-float64(x)/w.Zoom, cellY-float64(y)/w.Zoom
+}
+
+// Pan moves the view by dx, dy pixels, so that the world follows the mouse when it is dragged
+func (w *Window) Pan(dx, dy int32) {
+	w.ViewX -= float64(dx) / w.Zoom
+	w.ViewY -= float64(dy) / w.Zoom
+}
+
+// Viewport returns the cells that are in view, clipped to the world
+func (w *Window) Viewport() (x, y, width, height int) {
+	x0, y0 := int(math.Floor(w.ViewX)), int(math.Floor(w.ViewY))
+	x1, y1 := int(math.Ceil(w.ViewX+windowWidth/w.Zoom)), int(math.Ceil(w.ViewY+windowHeight/w.Zoom))
+	clip := func(v, n int) int {
+		if v < 0 {
+			return 0
+		}
+		if v > n {
+			return n
+		}
+		return v
+	}
+	x0, x1 = clip(x0, int(w.Width)), clip(x1, int(w.Width))
+	y0, y1 = clip(y0, int(w.Height)), clip(y1, int(w.Height))
+	return x0, y0, x1 - x0, y1 - y0
+}
+
+// axis returns the cell shown by each pixel along one side of the window, or -1 where it is outside the world,
+// and whether each pixel is on a grid line
+func (w *Window) axis(pixels int, view float64, cells int32) (cell []int, grid []bool) {
+	cell, grid = make([]int, pixels), make([]bool, pixels)
+	for i := range cell {
+		at := view + (float64(i)+0.5)/w.Zoom
+		cell[i] = int(math.Floor(at))
+		if at < 0 || cell[i] >= int(cells) {
+			cell[i] = -1
+		}
+		grid[i] = w.Zoom >= gridZoom && (at-math.Floor(at))*w.Zoom < 1
+	}
+	return cell, grid
+}
+
+// put sets the pixel x, y of the window, which is stored as blue, green, red and alpha bytes
+func (w *Window) put(x, y int, c color.RGBA) {
+	i := 4 * (y*windowWidth + x)
+	w.pixels[i+0], w.pixels[i+1], w.pixels[i+2], w.pixels[i+3] = c.B, c.G, c.R, 0xFF
+}
+
+// cellColour returns the colour the cell x, y is drawn in
+func (w *Window) cellColour(x, y int) color.RGBA {
+	alive := w.cells[y].GetBit(x)
+	switch w.colouring {
+	case ColourAge:
+		if alive {
+			return ageColour(palettes[w.palette], w.turn-int(w.flipped[y*int(w.Width)+x]))
+		}
+	case ColourChange:
+		changed := int(w.flipped[y*int(w.Width)+x]) > w.frameTurn
+		switch {
+		case changed && alive:
+			return bornColour
+		case changed:
+			return diedColour
+		}
+	}
+	if alive {
+		return w.alive
+	}
+	return w.dead
+}
+
+// FlipCell flips the cell x, y on the given turn
+func (w *Window) FlipCell(x, y, turn int) {
+	w.FlipPixel(x, y)
+	if w.flipped != nil {
+		w.flipped[y*int(w.Width)+x] = int32(turn)
+	}
+}
+
+// EndTurn moves on to the frame for the given turn, the cells flipped since the last frame count as changed
+func (w *Window) EndTurn(turn int) {
+	w.frameTurn, w.turn = w.turn, turn
+}
+
+// RenderFrame draws the cells in view into the window, with a legend when cells are coloured by age or change and
+// the overlay when it is shown
 func (w *Window) RenderFrame() {
-	err := w.texture.Update(nil, w.pixels, int(w.Width*4))
+	columns, columnGrid := w.axis(windowWidth, w.ViewX, w.Width)
+	rows, rowGrid := w.axis(windowHeight, w.ViewY, w.Height)
+	for py, y := range rows {
+		for px, x := range columns {
+			switch {
+			case x < 0 || y < 0:
+				w.put(px, py, outsideColour)
+			case columnGrid[px] || rowGrid[py]:
+				w.put(px, py, gridColour)
+			default:
+				w.put(px, py, w.cellColour(x, y))
+			}
+		}
+	}
+	w.drawLegend()
+	w.drawHUD()
+	err := w.texture.Update(nil, w.pixels, windowWidth*4)
 	util.Check(err)
 	err = w.renderer.Clear()
 	util.Check(err)
@@ -68,37 +260,47 @@ func (w *Window) PollEvent() sdl.Event {
 }
 
 func (w *Window) SetPixel(x, y int) {
-	width := int(w.Width)
-	w.pixels[4*(y*width+x)+0] = 0xFF
-	w.pixels[4*(y*width+x)+1] = 0xFF
-	w.pixels[4*(y*width+x)+2] = 0xFF
-	w.pixels[4*(y*width+x)+3] = 0xFF
+	w.cells[y].SetBit(x, true)
+}
+
+// Pixel reports whether the cell x, y is drawn as alive
+func (w *Window) Pixel(x, y int) bool {
+	return w.cells[y].GetBit(x)
+}
+
+// CellAt returns the cell under a pixel of the window. ok is false for pixels outside the world
+func (w *Window) CellAt(x, y int32) (cell util.Cell, ok bool) {
+	cellX := int(math.Floor(w.ViewX + (float64(x)+0.5)/w.Zoom))
+	cellY := int(math.Floor(w.ViewY + (float64(y)+0.5)/w.Zoom))
+	if cellX < 0 || cellY < 0 || cellX >= int(w.Width) || cellY >= int(w.Height) {
+		return util.Cell{}, false
+	}
+	return util.Cell{X: cellX, Y: cellY}, true
 }
 
 func (w *Window) FlipPixel(x, y int) {
 	if x < 0 || y < 0 || x >= int(w.Width) || y >= int(w.Height) {
 		panic(fmt.Sprintf("CellFlipped event at (%d, %d) is outside the bounds of the window.", x, y))
 	}
-
-	width := int(w.Width)
-	w.pixels[4*(y*width+x)+0] = ^w.pixels[4*(y*width+x)+0]
-	w.pixels[4*(y*width+x)+1] = ^w.pixels[4*(y*width+x)+1]
-	w.pixels[4*(y*width+x)+2] = ^w.pixels[4*(y*width+x)+2]
-	w.pixels[4*(y*width+x)+3] = ^w.pixels[4*(y*width+x)+3]
+	w.cells[y].SetBit(x, !w.cells[y].GetBit(x))
 }
 
 func (w *Window) CountPixels() int {
 	count := 0
-	for i := 0; i < int(w.Width) * int(w.Height) * 4; i += 4 {
-		if w.pixels[i] == 0xFF {
-			count++
+	for y := 0; y < int(w.Height); y++ {
+		for x := 0; x < int(w.Width); x++ {
+			if w.cells[y].GetBit(x) {
+				count++
+			}
 		}
 	}
 	return count
 }
 
 func (w *Window) ClearPixels() {
-	for i := range w.pixels {
-		w.pixels[i] = 0
+	for _, row := range w.cells {
+		for i := range row {
+			row[i] = 0
+		}
 	}
 }
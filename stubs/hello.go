@@ -0,0 +1,136 @@
+package stubs
+
+import (
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net/rpc"
+	"strings"
+)
+
+// ProtocolVersion is the version of the calls and structs in this package, raised whenever a change would stop an
+// older peer from understanding them. Peers from before the Hello call are version 1
+const ProtocolVersion = 2
+
+// MinProtocolVersion is the oldest version of a peer that can still be talked to, by leaving out what it lacks
+const MinProtocolVersion = 1
+
+// Encodings of the world that a peer can send and receive
+const (
+	EncodingBits   = "bits"   // Life worlds in util.BitArray rows
+	EncodingStates = "states" // worlds of rules with more than two states in util.StateArray rows
+)
+
+// Features that a peer may support on top of running Life a turn at a time on the torus
+const (
+	FeatureSparse     = "sparse"     // workers only recompute the tiles in WorkerRequest.ActiveTiles
+	FeatureBoundaries = "boundaries" // boundaries other than util.Torus
+	FeatureThreads    = "threads"    // Request.Threads and WorkerRequest.Threads are honoured
+	FeatureHashLife   = "hashlife"   // the EngineHashLife engine
+	FeatureEdits      = "edits"      // SetRegion, StampPattern and ClearWorld
+	FeatureRegions    = "regions"    // GetRegion
+	FeatureStats      = "stats"      // GetWorkerStats
+	FeatureAliveAt    = "alive-at"   // GetAliveCountAt
+)
+
+// WorkerFeatures are the features that depend on the workers rather than the broker
+var WorkerFeatures = []string{FeatureSparse, FeatureBoundaries, FeatureThreads}
+
+// controller to broker and broker to worker
+
+var BrokerHello = "GameOfLifeOperations.Hello"
+var WorkerHello = "WorkerOperations.Hello"
+
+// Hello is sent by the caller at the start of a connection and answered by the other end with its own, so that
+// each can tell whether it can talk to the other and what it may ask for. Rules are the families of rule.Families
+// that can be run
+type Hello struct {
+	Version    int
+	MinVersion int
+	Rules      []string
+	Encodings  []string
+	Features   []string
+}
+
+// Legacy is what a peer from before the Hello call supports, Life on the torus one turn at a time
+var Legacy = Hello{Version: 1, MinVersion: 1, Rules: []string{"life"}, Encodings: []string{EncodingBits}}
+
+// Has reports whether feature is one of the features of h
+func (h Hello) Has(feature string) bool {
+	return contains(h.Features, feature)
+}
+
+// HasRule reports whether the rule family is one of the rules of h
+func (h Hello) HasRule(family string) bool {
+	return contains(h.Rules, family)
+}
+
+// HasEncoding reports whether encoding is one of the encodings of h
+func (h Hello) HasEncoding(encoding string) bool {
+	return contains(h.Encodings, encoding)
+}
+
+// Negotiate returns what both local and remote support at the lower of their versions, or an error if either is
+// older than the other can talk to
+func Negotiate(local, remote Hello) (Hello, error) {
+	if remote.Version < local.MinVersion {
+		return Hello{}, fmt.Errorf("protocol version %d is too old, at least %d is needed", remote.Version, local.MinVersion)
+	}
+	if local.Version < remote.MinVersion {
+		return Hello{}, fmt.Errorf("protocol version %d is too old for a peer that needs at least %d", local.Version, remote.MinVersion)
+	}
+	agreed := Hello{
+		Version:    local.Version,
+		MinVersion: local.MinVersion,
+		Rules:      Intersect(local.Rules, remote.Rules),
+		Encodings:  Intersect(local.Encodings, remote.Encodings),
+		Features:   Intersect(local.Features, remote.Features),
+	}
+	if remote.Version < agreed.Version {
+		agreed.Version = remote.Version
+	}
+	if remote.MinVersion > agreed.MinVersion {
+		agreed.MinVersion = remote.MinVersion
+	}
+	if !agreed.HasEncoding(EncodingBits) {
+		return Hello{}, fmt.Errorf("no encoding of the world in common, %v against %v", local.Encodings, remote.Encodings)
+	}
+	return agreed, nil
+}
+
+// CallHello makes the Hello call named by method and negotiates with the answer. A peer from before the Hello call
+// is taken to be Legacy, whether it is called over net/rpc or is a gRPC server that leaves the call unimplemented
+func CallHello(client Caller, method string, local Hello) (Hello, error) {
+	remote := new(Hello)
+	if err := client.Call(method, local, remote); err != nil {
+		if e, ok := err.(rpc.ServerError); ok && strings.HasPrefix(string(e), "rpc: can't find") {
+			return Negotiate(local, Legacy)
+		}
+		if status.Code(err) == codes.Unimplemented {
+			return Negotiate(local, Legacy)
+		}
+		return Hello{}, err
+	}
+	return Negotiate(local, *remote)
+}
+
+// Intersect returns the strings of a that are also in b, in the order of a
+func Intersect(a, b []string) []string {
+	both := make([]string, 0, len(a))
+	for _, s := range a {
+		if contains(b, s) {
+			both = append(both, s)
+		}
+	}
+	return both
+}
+
+// contains reports whether s is one of list
+func contains(list []string, s string) bool {
+	for _, l := range list {
+		if l == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,126 @@
+package stubs
+
+import (
+	"net"
+	"net/rpc"
+	"reflect"
+	"testing"
+)
+
+// peers are the versions of a controller, broker or worker that may meet each other
+var peers = map[string]Hello{
+	"legacy": Legacy,
+	"current": {Version: ProtocolVersion, MinVersion: MinProtocolVersion, Rules: []string{"life", "generations"},
+		Encodings: []string{EncodingBits, EncodingStates}, Features: []string{FeatureSparse, FeatureEdits}},
+	"newer": {Version: ProtocolVersion + 1, MinVersion: MinProtocolVersion, Rules: []string{"life", "ltl"},
+		Encodings: []string{EncodingBits, EncodingStates, "compressed"}, Features: []string{FeatureEdits, "watch"}},
+	"strict": {Version: ProtocolVersion + 1, MinVersion: ProtocolVersion + 1, Rules: []string{"life"},
+		Encodings: []string{EncodingBits}},
+	"no bits": {Version: ProtocolVersion, MinVersion: MinProtocolVersion, Rules: []string{"life"},
+		Encodings: []string{"compressed"}},
+}
+
+// TestNegotiate checks every pair of peers, that they agree on the lower version and only what both support, and
+// that a peer refuses one that is too old for it whichever end it is on
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		local, remote string
+		version       int
+		rules         []string
+		encodings     []string
+		features      []string
+	}{
+		{"legacy", "legacy", 1, []string{"life"}, []string{EncodingBits}, []string{}},
+		{"current", "legacy", 1, []string{"life"}, []string{EncodingBits}, []string{}},
+		{"legacy", "current", 1, []string{"life"}, []string{EncodingBits}, []string{}},
+		{"current", "current", ProtocolVersion, []string{"life", "generations"}, []string{EncodingBits, EncodingStates}, []string{FeatureSparse, FeatureEdits}},
+		{"current", "newer", ProtocolVersion, []string{"life"}, []string{EncodingBits, EncodingStates}, []string{FeatureEdits}},
+		{"newer", "current", ProtocolVersion, []string{"life"}, []string{EncodingBits, EncodingStates}, []string{FeatureEdits}},
+		{"newer", "legacy", 1, []string{"life"}, []string{EncodingBits}, []string{}},
+		{"current", "strict", -1, nil, nil, nil},
+		{"strict", "current", -1, nil, nil, nil},
+		{"strict", "legacy", -1, nil, nil, nil},
+		{"legacy", "strict", -1, nil, nil, nil},
+		{"strict", "newer", ProtocolVersion + 1, []string{"life"}, []string{EncodingBits}, []string{}},
+		{"current", "no bits", -1, nil, nil, nil},
+	}
+	for _, test := range tests {
+		agreed, err := Negotiate(peers[test.local], peers[test.remote])
+		if test.version < 0 {
+			if err == nil {
+				t.Errorf("%s and %s agreed on %+v, want an error", test.local, test.remote, agreed)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s and %s failed: %v", test.local, test.remote, err)
+			continue
+		}
+		want := Hello{Version: test.version, Rules: test.rules, Encodings: test.encodings, Features: test.features}
+		agreed.MinVersion = 0
+		if !reflect.DeepEqual(agreed, want) {
+			t.Errorf("%s and %s agreed on %+v, want %+v", test.local, test.remote, agreed, want)
+		}
+	}
+}
+
+// Current answers Hello like a peer of this version
+type Current struct{}
+
+func (Current) Hello(_ Hello, res *Hello) error {
+	*res = peers["current"]
+	return nil
+}
+
+// Old is a peer from before the Hello call
+type Old struct{}
+
+func (Old) Ping(_ struct{}, _ *struct{}) error {
+	return nil
+}
+
+// serve returns a client of an RPC server with the service registered, which stops accepting connections once the
+// client has connected
+func serve(t *testing.T, service interface{}) *rpc.Client {
+	server := rpc.NewServer()
+	if err := server.Register(service); err != nil {
+		t.Fatal(err)
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = listener.Close()
+	}()
+	go server.Accept(listener)
+	client, err := rpc.Dial("tcp", listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+// TestCallHello checks that a peer without the Hello call is taken to be Legacy, and that one with it is asked
+func TestCallHello(t *testing.T) {
+	local := peers["newer"]
+	agreed, err := CallHello(serve(t, Old{}), "Old.Hello", local)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if agreed.Version != 1 || agreed.HasEncoding(EncodingStates) || len(agreed.Features) != 0 {
+		t.Errorf("an old peer agreed on %+v, want Legacy", agreed)
+	}
+
+	agreed, err = CallHello(serve(t, Current{}), "Current.Hello", local)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if agreed.Version != ProtocolVersion || !agreed.Has(FeatureEdits) || agreed.HasRule("generations") {
+		t.Errorf("a current peer agreed on %+v", agreed)
+	}
+
+	if _, err := CallHello(serve(t, Current{}), "Current.Hello", peers["strict"]); err == nil {
+		t.Error("a strict peer talked to a current one")
+	}
+}
@@ -5,10 +5,24 @@ import (
 )
 
 const (
-	Alive   = true
-	Threads = 4
+	Alive      = true
+	Threads    = 4   // number of workers the broker splits the world between
+	MaxThreads = 256 // most goroutines the broker will ask each worker to split its strip between
 )
 
+// Engines that the broker can use to advance the world
+const (
+	EngineStep     = "step"     // one turn at a time, split between the workers
+	EngineHashLife = "hashlife" // HashLife in the broker, jumping ahead many turns at once
+)
+
+// Caller makes the calls named below, taking the request and a pointer to the response as they are declared here.
+// *rpc.Client is a Caller, and the Client of the proto package is one that calls over gRPC
+type Caller interface {
+	Call(serviceMethod string, args interface{}, reply interface{}) error
+	Close() error
+}
+
 // distributor to broker
 
 var RunGameOfLife = "GameOfLifeOperations.RunGameOfLife"
@@ -17,19 +31,61 @@ var GetCurrentWorld = "GameOfLifeOperations.GetCurrentWorld"
 var HaltTurns = "GameOfLifeOperations.HaltTurns"
 var PauseServer = "GameOfLifeOperations.PauseServer"
 var KillClients = "GameOfLifeOperations.KillClients"
+var GetWorkerStats = "GameOfLifeOperations.GetWorkerStats"
+var GetAliveCountAt = "GameOfLifeOperations.GetAliveCountAt"
+var SetRegion = "GameOfLifeOperations.SetRegion"
+var StampPattern = "GameOfLifeOperations.StampPattern"
+var ClearWorld = "GameOfLifeOperations.ClearWorld"
+var GetRegion = "GameOfLifeOperations.GetRegion"
 
+// Response contains the final world, in States instead of NextWorld when the rule is not Life
 type Response struct {
 	NextWorld      []util.BitArray
+	NextStates     []util.StateArray
 	CompletedTurns int
 }
 
-// Request contains num of turns, 2d slice (initial state), size of image
+// Request contains num of turns, 2d slice (initial state), size of image.
+// Rule is the rule string, and for any rule other than Life the initial state is in States instead of World
 type Request struct {
 	Turns       int
 	ImageWidth  int
 	ImageHeight int
 	World       []util.BitArray
 	Resume      bool
+	Engine      string
+	Boundary    util.Boundary
+	Rule        string
+	States      []util.StateArray
+	Threads     int // how many goroutines each worker should use, 0 leaves it to the -threads flag of the workers
+}
+
+// AliveCountAtRequest asks for the number of alive cells on a turn that may not have been reached yet
+type AliveCountAtRequest struct {
+	Turn int
+}
+
+// RegionRequest sets every cell of the Width by Height rectangle whose top left cell is X, Y to State,
+// where 0 clears the cells and 1 makes them alive. GetRegion only reads the rectangle and ignores State
+type RegionRequest struct {
+	X, Y          int
+	Width, Height int
+	State         uint8
+}
+
+// StampRequest writes a pattern in RLE over the world with the top left of its bounding box at X, Y.
+// The dead cells of the pattern are written as well, so the stamp replaces whatever was under it
+type StampRequest struct {
+	X, Y int
+	RLE  string
+}
+
+// RegionResponse contains the cells of a rectangle of the world that are not dead, packed one bit per cell.
+// Rows[0] is row Y of the world and each row starts at column X, where X is a multiple of 8
+type RegionResponse struct {
+	X, Y           int
+	Rows           []util.BitArray
+	CompletedTurns int
 }
 
 type AliveCellsResponse struct {
@@ -39,6 +95,7 @@ type AliveCellsResponse struct {
 
 type CurrentWorldResponse struct {
 	World          []util.BitArray
+	States         []util.StateArray
 	CompletedTurns int
 }
 
@@ -46,17 +103,46 @@ type PauseServerResponse struct {
 	CompletedTurns int
 }
 
+// WorkerStatsResponse contains the rows given to each worker, the measured nanoseconds per row of each worker and
+// how many workers the broker is connected to
+type WorkerStatsResponse struct {
+	Split          []int
+	NsPerRow       []float64
+	CompletedTurns int
+	Workers        int
+}
+
 // broker to worker
 
 var Worker = "WorkerOperations.Worker"
 var KillWorker = "WorkerOperations.KillWorker"
 
+// WorkerRequest contains a strip of the world with one extra row above and below it.
+// When Sparse is set only ActiveTiles are recomputed and the strip is not sent, instead Halos holds the cells that
+// each of ActiveTiles reads, as made by util.Halos.
+// West and East are the cells beyond the left and right of each row of InPart, when the Boundary needs them.
+// For any Rule other than Life the strip is in InStates with as many extra rows as the radius of the rule, and
+// WestStates and EastStates hold that many cells beyond each side of each row.
+// Threads is the number of goroutines to split the strip between, or 0 for the default of the worker
 type WorkerRequest struct {
-	Scale      int
-	WorldWidth int
-	InPart     []util.BitArray
+	Scale                  int
+	WorldWidth             int
+	InPart                 []util.BitArray
+	Sparse                 bool
+	StartY                 int
+	ActiveTiles            []util.TileIndex
+	Halos                  []util.Tile
+	Boundary               util.Boundary
+	West, East             []bool
+	Rule                   string
+	InStates               []util.StateArray
+	WestStates, EastStates [][]uint8
+	Threads                int
 }
 
+// WorkerResponse contains the next state of the strip, or only the tiles that changed when the request was Sparse
 type WorkerResponse struct {
-	OutPart []util.BitArray
+	OutPart   []util.BitArray
+	Tiles     []util.Tile
+	OutStates []util.StateArray
 }
@@ -0,0 +1,100 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"uk.ac.bris.cs/gameoflife/gol"
+)
+
+// Limits on how often the terminal is drawn and its size is checked, so that fast turns do not flood it
+const (
+	frameInterval = 50 * time.Millisecond
+	sizeInterval  = time.Second
+)
+
+// readKeys sends the p, s, q and k keys typed into the terminal to keyPresses
+func readKeys(keyPresses chan<- rune) {
+	buffer := make([]byte, 1)
+	for {
+		if _, err := os.Stdin.Read(buffer); err != nil {
+			return
+		}
+		switch buffer[0] {
+		case 'p', 's', 'q', 'k':
+			keyPresses <- rune(buffer[0])
+		}
+	}
+}
+
+// Run shows the world in the terminal until the final turn, with a status line of the turn, alive cells, state
+// and the latest event under it. Keys typed into the terminal are sent to keyPresses
+func Run(p gol.Params, events <-chan gol.Event, keyPresses chan<- rune) {
+	cols, rows := terminalSize()
+	screen := NewScreen(p.ImageWidth, p.ImageHeight, cols, rows)
+	restore, err := rawMode()
+	if err != nil {
+		fmt.Println(err)
+		restore = func() {}
+	}
+	// the terminal is put back if the program is interrupted, as it would be left without echo otherwise
+	interrupts := make(chan os.Signal, 1)
+	signal.Notify(interrupts, os.Interrupt)
+	go func() {
+		<-interrupts
+		restore()
+		fmt.Print("\x1b[?25h\n")
+		os.Exit(1)
+	}()
+	defer func() {
+		signal.Stop(interrupts)
+		restore()
+		fmt.Print("\x1b[?25h\n")
+	}()
+	go readKeys(keyPresses)
+
+	turn, alive, state, message := 0, "-", gol.Executing, ""
+	var drawn, sized time.Time
+	draw := func(force bool) {
+		now := time.Now()
+		if !force && now.Sub(drawn) < frameInterval {
+			return
+		}
+		if now.Sub(sized) >= sizeInterval {
+			screen.Cols, screen.Rows = terminalSize()
+			sized = now
+		}
+		screen.Status = fmt.Sprintf("turn %d  alive %s  %v  %s  [p]ause [s]ave [q]uit [k]ill", turn, alive, state, message)
+		fmt.Print(screen.Frame())
+		drawn = now
+	}
+	fmt.Print("\x1b[2J\x1b[?25l")
+	draw(true)
+
+	for event := range events {
+		switch e := event.(type) {
+		case gol.CellFlipped:
+			screen.FlipCell(e.Cell.X, e.Cell.Y)
+		case gol.TurnComplete:
+			turn = e.CompletedTurns
+			draw(false)
+		case gol.FinalTurnComplete:
+			turn = e.CompletedTurns
+			draw(true)
+			return
+		case gol.AliveCellsCount:
+			turn, alive = e.CompletedTurns, fmt.Sprint(e.CellsCount)
+			draw(true)
+		case gol.StateChange:
+			turn, state = e.CompletedTurns, e.NewState
+			draw(true)
+		default:
+			if len(event.String()) > 0 {
+				message = event.String()
+				draw(true)
+			}
+		}
+	}
+}
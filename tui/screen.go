@@ -0,0 +1,114 @@
+// Package tui shows the world in a terminal, for machines that cannot open an SDL window. Cells are drawn with
+// half blocks, two cells to a character, or with braille dots, eight to a character, for worlds that would not
+// fit otherwise. Worlds too large even for braille are scaled down, with a dot alive if any cell it covers is.
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"uk.ac.bris.cs/gameoflife/util"
+)
+
+// Screen keeps the cells shown in the terminal and the status line under them
+type Screen struct {
+	Width, Height int
+	Cols, Rows    int    // size of the terminal in characters
+	Status        string // shown on the last row of the terminal
+	cells         []util.BitArray
+}
+
+// NewScreen returns a screen for a world of width by height cells in a terminal of cols by rows characters
+func NewScreen(width, height, cols, rows int) *Screen {
+	cells := make([]util.BitArray, height)
+	for y := range cells {
+		cells[y] = util.NewBitArray((width + 7) &^ 7)
+	}
+	return &Screen{Width: width, Height: height, Cols: cols, Rows: rows, cells: cells}
+}
+
+// FlipCell flips the cell x, y
+func (s *Screen) FlipCell(x, y int) {
+	if x < 0 || y < 0 || x >= s.Width || y >= s.Height {
+		panic(fmt.Sprintf("CellFlipped event at (%d, %d) is outside the bounds of the screen.", x, y))
+	}
+	s.cells[y].SetBit(x, !s.cells[y].GetBit(x))
+}
+
+// layout returns whether the world is drawn in braille, and how many cells wide and tall each dot or half block
+// covers, choosing the largest drawing that fits in the terminal above the status line
+func (s *Screen) layout() (braille bool, scale int) {
+	rows := s.Rows - 1
+	if rows < 1 {
+		rows = 1
+	}
+	if s.Width <= s.Cols && s.Height <= 2*rows {
+		return false, 1
+	}
+	scale = 1
+	for (s.Width+2*scale-1)/(2*scale) > s.Cols || (s.Height+4*scale-1)/(4*scale) > rows {
+		scale++
+	}
+	return true, scale
+}
+
+// dot reports whether any cell of the scale by scale block with its top left at x, y is alive
+func (s *Screen) dot(x, y, scale int) bool {
+	for j := y; j < y+scale && j < s.Height; j++ {
+		for i := x; i < x+scale && i < s.Width; i++ {
+			if s.cells[j].GetBit(i) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// brailleDots are the bits of a braille character for each dot, indexed by [row][column]
+var brailleDots = [4][2]rune{{0x01, 0x08}, {0x02, 0x10}, {0x04, 0x20}, {0x40, 0x80}}
+
+// halfBlocks are the characters for a top and bottom cell, indexed by top + 2*bottom
+var halfBlocks = [4]rune{' ', '▀', '▄', '█'}
+
+// Frame returns the text that draws the world and the status line from the top left of the terminal,
+// clearing what is left of each line
+func (s *Screen) Frame() string {
+	braille, scale := s.layout()
+	charWidth, charHeight := scale, 2*scale
+	if braille {
+		charWidth, charHeight = 2*scale, 4*scale
+	}
+	var b strings.Builder
+	b.WriteString("\x1b[H")
+	for y := 0; y < s.Height; y += charHeight {
+		for x := 0; x < s.Width; x += charWidth {
+			if braille {
+				r := rune(0x2800)
+				for row := 0; row < 4; row++ {
+					for column := 0; column < 2; column++ {
+						if s.dot(x+column*scale, y+row*scale, scale) {
+							r |= brailleDots[row][column]
+						}
+					}
+				}
+				b.WriteRune(r)
+			} else {
+				i := 0
+				if s.dot(x, y, scale) {
+					i |= 1
+				}
+				if s.dot(x, y+scale, scale) {
+					i |= 2
+				}
+				b.WriteRune(halfBlocks[i])
+			}
+		}
+		b.WriteString("\x1b[K\r\n")
+	}
+	status := s.Status
+	if len(status) > s.Cols {
+		status = status[:s.Cols]
+	}
+	b.WriteString(status + "\x1b[K\x1b[J")
+	return b.String()
+}
@@ -0,0 +1,47 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+)
+
+// lines returns the rows of characters in a frame, without the escape codes
+func lines(frame string) []string {
+	frame = strings.TrimPrefix(frame, "\x1b[H")
+	var rows []string
+	for _, row := range strings.Split(frame, "\r\n") {
+		rows = append(rows, strings.Split(row, "\x1b[")[0])
+	}
+	return rows
+}
+
+// TestHalfBlocks tests that a world that fits is drawn with two cells to a character
+func TestHalfBlocks(t *testing.T) {
+	s := NewScreen(3, 2, 80, 24)
+	s.FlipCell(0, 0)
+	s.FlipCell(1, 1)
+	s.FlipCell(2, 0)
+	s.FlipCell(2, 1)
+	s.Status = "status"
+	got := lines(s.Frame())
+	want := []string{"▀▄█", "status"}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+// TestBraille tests that a world too large for half blocks is drawn in braille, scaled down until it fits
+func TestBraille(t *testing.T) {
+	s := NewScreen(16, 16, 4, 3)
+	s.FlipCell(0, 0)
+	s.FlipCell(15, 15)
+	braille, scale := s.layout()
+	if !braille || scale != 2 {
+		t.Fatalf("got braille %v scale %d, want braille scale 2", braille, scale)
+	}
+	got := lines(s.Frame())
+	want := []string{"⠁⠀⠀⠀", "⠀⠀⠀⢀", ""}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
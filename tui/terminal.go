@@ -0,0 +1,51 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Size of the terminal when it cannot be found, such as when the output is not a terminal
+const (
+	defaultCols = 80
+	defaultRows = 24
+)
+
+// stty runs stty on the terminal of stdin, returning what it prints
+func stty(args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = os.Stdin
+	out, err := cmd.Output()
+	return strings.TrimSpace(string(out)), err
+}
+
+// terminalSize returns the number of columns and rows of the terminal
+func terminalSize() (cols, rows int) {
+	out, err := stty("size")
+	if err != nil {
+		return defaultCols, defaultRows
+	}
+	if _, err := fmt.Sscan(out, &rows, &cols); err != nil || cols <= 0 || rows <= 0 {
+		return defaultCols, defaultRows
+	}
+	return cols, rows
+}
+
+// rawMode stops the terminal buffering lines and echoing key presses, so that each key is read as it is pressed,
+// while ctrl-c still interrupts. It returns a function that puts the terminal back as it was
+func rawMode() (restore func(), err error) {
+	saved, err := stty("-g")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := stty("-icanon", "-echo", "min", "1"); err != nil {
+		return nil, err
+	}
+	return func() {
+		if _, err := stty(saved); err != nil {
+			fmt.Println(err)
+		}
+	}, nil
+}
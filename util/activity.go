@@ -0,0 +1,225 @@
+package util
+
+// TileSize is the width and height in cells of the tiles used to track which parts of the world are active
+const TileSize = 16
+
+// TileIndex is the position of a tile in the grid of tiles covering the world
+type TileIndex struct {
+	X, Y int
+}
+
+// Tile is a rectangle of the world, X and Y are the coordinates of its top left cell
+type Tile struct {
+	X, Y int
+	Rows []BitArray
+}
+
+// Activity tracks which tiles of a world are different to how they were two turns ago.
+// A tile that, along with its neighbours, is the same as two turns ago will repeat the turn before,
+// so still lifes and period 2 oscillators do not need to be recomputed
+type Activity struct {
+	width, height  int
+	tilesX, tilesY int
+	boundary       Boundary
+	changed        []bool     // changed[y*tilesX+x] is true if tile x, y is different to two turns ago
+	previous       []BitArray // the world on the turn before
+	next           []BitArray // scratch space for the next world
+}
+
+// NewActivity creates the tiles for a world, marking them all as changed so the first turn is computed in full
+func NewActivity(world []BitArray, boundary Boundary) *Activity {
+	a := &Activity{
+		width:    0,
+		height:   len(world),
+		boundary: boundary,
+	}
+	if len(world) > 0 {
+		a.width = world[0].Len()
+	}
+	a.tilesX = (a.width + TileSize - 1) / TileSize
+	a.tilesY = (a.height + TileSize - 1) / TileSize
+	a.changed = make([]bool, a.tilesX*a.tilesY)
+	a.previous = cloneWorld(world)
+	a.next = cloneWorld(world)
+	a.MarkAll()
+	return a
+}
+
+// cloneWorld makes a deep copy of a world
+func cloneWorld(world []BitArray) []BitArray {
+	clone := make([]BitArray, len(world))
+	for y, row := range world {
+		clone[y] = make(BitArray, len(row))
+		copy(clone[y], row)
+	}
+	return clone
+}
+
+// wrap ensures that values outside 0 to n-1 wrap around
+func wrap(value, n int) int {
+	return ((value % n) + n) % n
+}
+
+// Fits reports whether the tiles cover a world of the given size and boundary
+func (a *Activity) Fits(width, height int, boundary Boundary) bool {
+	return a.width == width && a.height == height && a.boundary == boundary
+}
+
+// MarkAll marks every tile as changed
+func (a *Activity) MarkAll() {
+	for i := range a.changed {
+		a.changed[i] = true
+	}
+}
+
+// MarkCell marks the tile containing the cell x, y as changed
+func (a *Activity) MarkCell(x, y int) {
+	a.changed[(y/TileSize)*a.tilesX+x/TileSize] = true
+}
+
+// Active returns the tiles that changed along with their neighbours, following the boundary at the edges of the world
+func (a *Activity) Active() []bool {
+	active := make([]bool, len(a.changed))
+	for ty := 0; ty < a.tilesY; ty++ {
+		for tx := 0; tx < a.tilesX; tx++ {
+			if !a.changed[ty*a.tilesX+tx] {
+				continue
+			}
+			if a.boundary == Torus {
+				for dy := -1; dy <= 1; dy++ {
+					for dx := -1; dx <= 1; dx++ {
+						active[wrap(ty+dy, a.tilesY)*a.tilesX+wrap(tx+dx, a.tilesX)] = true
+					}
+				}
+				continue
+			}
+			// the ring of cells around the tile may land anywhere once the edges are crossed
+			active[ty*a.tilesX+tx] = true
+			x0, x1, y0, y1 := TileBounds(TileIndex{X: tx, Y: ty}, a.width, 0, a.height)
+			for y := y0 - 1; y <= y1; y++ {
+				for x := x0 - 1; x <= x1; x++ {
+					if y != y0-1 && y != y1 && x != x0-1 && x != x1 {
+						continue
+					}
+					if wx, wy, ok := a.boundary.Wrap(x, y, a.width, a.height); ok {
+						active[(wy/TileSize)*a.tilesX+wx/TileSize] = true
+					}
+				}
+			}
+		}
+	}
+	return active
+}
+
+// StripTiles returns the active tiles that overlap the rows startY (inclusive) to endY (exclusive)
+func (a *Activity) StripTiles(active []bool, startY, endY int) []TileIndex {
+	tiles := make([]TileIndex, 0)
+	if startY >= endY {
+		return tiles
+	}
+	for ty := startY / TileSize; ty <= (endY-1)/TileSize; ty++ {
+		for tx := 0; tx < a.tilesX; tx++ {
+			if active[ty*a.tilesX+tx] {
+				tiles = append(tiles, TileIndex{X: tx, Y: ty})
+			}
+		}
+	}
+	return tiles
+}
+
+// TileBounds returns the cells covered by a tile clipped to the rows startY to endY, the ends are exclusive
+func TileBounds(tile TileIndex, width, startY, endY int) (x0, x1, y0, y1 int) {
+	x0, x1 = tile.X*TileSize, (tile.X+1)*TileSize
+	y0, y1 = tile.Y*TileSize, (tile.Y+1)*TileSize
+	if x1 > width {
+		x1 = width
+	}
+	if y0 < startY {
+		y0 = startY
+	}
+	if y1 > endY {
+		y1 = endY
+	}
+	return
+}
+
+// Halos returns the cells that each of the tiles, clipped to the rows startY to endY, reads from. A halo is its tile
+// with a byte of cells beyond the left and right sides and a row beyond the top and bottom, following the boundary
+// beyond the edges of the world, and its X and Y are those of its top left cell, which may be outside the world.
+// Rows that are inside the world are shared with it
+func Halos(world []BitArray, boundary Boundary, tiles []TileIndex, startY, endY int) []Tile {
+	width, height := world[0].Len(), len(world)
+	halos := make([]Tile, len(tiles))
+	for i, tile := range tiles {
+		x0, x1, y0, y1 := TileBounds(tile, width, startY, endY)
+		halo := Tile{X: x0 - 8, Y: y0 - 1, Rows: make([]BitArray, y1-y0+2)}
+		for j := range halo.Rows {
+			y := halo.Y + j
+			if y >= 0 && y < height && x0 >= 8 && x1+8 <= width {
+				halo.Rows[j] = world[y][x0/8-1 : x1/8+1]
+				continue
+			}
+			row := NewBitArray(x1 - x0 + 16)
+			for x := 0; x < row.Len(); x++ {
+				if wx, wy, ok := boundary.Wrap(halo.X+x, y, width, height); ok {
+					row.SetBit(x, world[wy].GetBit(wx))
+				}
+			}
+			halo.Rows[j] = row
+		}
+		halos[i] = halo
+	}
+	return halos
+}
+
+// copyTile copies the cells of a tile from one world to another
+func copyTile(dst, src []BitArray, x0, x1, y0, y1 int) {
+	for y := y0; y < y1; y++ {
+		copy(dst[y][x0/8:x1/8], src[y][x0/8:x1/8])
+	}
+}
+
+// sameTile reports whether a tile has the same cells in two worlds
+func sameTile(a, b []BitArray, x0, x1, y0, y1 int) bool {
+	for y := y0; y < y1; y++ {
+		for i := x0 / 8; i < x1/8; i++ {
+			if a[y][i] != b[y][i] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Advance moves world on to the next turn. The active tiles have been recomputed and changed contains those that
+// are different to world, every other tile repeats the turn before
+func (a *Activity) Advance(world []BitArray, active []bool, changed []Tile) {
+	// inactive tiles repeat the previous turn, active tiles stay the same unless a worker changed them
+	for y := range a.next {
+		copy(a.next[y], a.previous[y])
+	}
+	for i, isActive := range active {
+		if isActive {
+			x0, x1, y0, y1 := TileBounds(TileIndex{X: i % a.tilesX, Y: i / a.tilesX}, a.width, 0, a.height)
+			copyTile(a.next, world, x0, x1, y0, y1)
+		}
+	}
+	for _, tile := range changed {
+		for y, row := range tile.Rows {
+			copy(a.next[tile.Y+y][tile.X/8:], row)
+		}
+	}
+
+	for i, isActive := range active {
+		a.changed[i] = false
+		if isActive {
+			x0, x1, y0, y1 := TileBounds(TileIndex{X: i % a.tilesX, Y: i / a.tilesX}, a.width, 0, a.height)
+			a.changed[i] = !sameTile(a.next, a.previous, x0, x1, y0, y1)
+		}
+	}
+
+	for y := range world {
+		copy(a.previous[y], world[y])
+		copy(world[y], a.next[y])
+	}
+}
@@ -0,0 +1,183 @@
+package util
+
+import "fmt"
+
+// Boundary is how the edges of the world are joined together
+type Boundary int
+
+const (
+	Torus        Boundary = iota // left joins right and top joins bottom
+	DeadBorder                   // every cell beyond the edges is dead
+	Reflect                      // the edges act as mirrors, so a cell d beyond an edge is the cell d-1 inside it
+	KleinBottle                  // left joins right, and top joins bottom with a twist that mirrors left and right
+	CrossSurface                 // both pairs of edges are joined with a twist
+)
+
+// ParseBoundary returns the Boundary with the given name
+func ParseBoundary(name string) (Boundary, error) {
+	for b := Torus; b <= CrossSurface; b++ {
+		if b.String() == name {
+			return b, nil
+		}
+	}
+	return Torus, fmt.Errorf("unknown boundary %q, expected torus, dead, reflect, klein or cross", name)
+}
+
+func (b Boundary) String() string {
+	switch b {
+	case Torus:
+		return "torus"
+	case DeadBorder:
+		return "dead"
+	case Reflect:
+		return "reflect"
+	case KleinBottle:
+		return "klein"
+	case CrossSurface:
+		return "cross"
+	default:
+		return "Incorrect Boundary"
+	}
+}
+
+// Wrap maps a cell that may be beyond the edges of a width by height world onto the cell it touches.
+// The x edges are crossed before the y edges, each crossing of a twisted edge mirrors the other coordinate, and ok
+// is false if the cell is always dead
+func (b Boundary) Wrap(x, y, width, height int) (wx, wy int, ok bool) {
+	if x >= 0 && x < width && y >= 0 && y < height {
+		return x, y, true
+	}
+	switch b {
+	case DeadBorder:
+		return x, y, false
+	case Reflect:
+		return Mirror(x, width), Mirror(y, height), true
+	}
+
+	if x < 0 || x >= width {
+		if b == CrossSurface && twisted(x, width) {
+			y = height - 1 - y
+		}
+		x = wrap(x, width)
+	}
+	if y < 0 || y >= height {
+		if (b == KleinBottle || b == CrossSurface) && twisted(y, height) {
+			x = width - 1 - x
+		}
+		y = wrap(y, height)
+	}
+	return x, y, true
+}
+
+// Mirror maps values outside 0 to n-1 back inside as if both ends were mirrors, so -1 is 0, -2 is 1 and n is n-1
+func Mirror(value, n int) int {
+	value = wrap(value, 2*n)
+	if value >= n {
+		value = 2*n - 1 - value
+	}
+	return value
+}
+
+// twisted reports whether reaching value from inside 0 to n-1 crosses the ends an odd number of times
+func twisted(value, n int) bool {
+	crossings := value / n
+	if value < 0 {
+		crossings = (value+1)/n - 1
+	}
+	return crossings%2 != 0
+}
+
+// Row returns row y of the world, building the row beyond the top or bottom edge when y is outside the world
+func (b Boundary) Row(world []BitArray, y int) BitArray {
+	height := len(world)
+	if y >= 0 && y < height {
+		return world[y]
+	}
+	width := world[0].Len()
+	row := NewBitArray(width)
+	switch b {
+	case DeadBorder:
+		return row
+	case Reflect:
+		return world[Mirror(y, height)]
+	case KleinBottle, CrossSurface:
+		source := world[wrap(y, height)]
+		if !twisted(y, height) {
+			return source
+		}
+		for x := 0; x < width; x++ {
+			row.SetBit(width-1-x, source.GetBit(x))
+		}
+		return row
+	default:
+		return world[wrap(y, height)]
+	}
+}
+
+// Edges returns the cells just beyond the left and right edges of n rows starting at row startY, which may be
+// outside the world. Only the cross-surface needs these, as the other boundaries can wrap columns within a row
+func (b Boundary) Edges(world []BitArray, startY, n int) (west, east []bool) {
+	if b != CrossSurface {
+		return nil, nil
+	}
+	width, height := world[0].Len(), len(world)
+	west, east = make([]bool, n), make([]bool, n)
+	for i := 0; i < n; i++ {
+		if x, y, ok := b.Wrap(-1, startY+i, width, height); ok {
+			west[i] = world[y].GetBit(x)
+		}
+		if x, y, ok := b.Wrap(width, startY+i, width, height); ok {
+			east[i] = world[y].GetBit(x)
+		}
+	}
+	return
+}
+
+// StateRow is Row for worlds where each cell has one of several states
+func (b Boundary) StateRow(world []StateArray, y int) StateArray {
+	height := len(world)
+	if y >= 0 && y < height {
+		return world[y]
+	}
+	width := world[0].Len()
+	row := NewStateArray(width, world[0].Bits)
+	switch b {
+	case DeadBorder:
+		return row
+	case Reflect:
+		return world[Mirror(y, height)]
+	case KleinBottle, CrossSurface:
+		source := world[wrap(y, height)]
+		if !twisted(y, height) {
+			return source
+		}
+		for x := 0; x < width; x++ {
+			row.Set(width-1-x, source.Get(x))
+		}
+		return row
+	default:
+		return world[wrap(y, height)]
+	}
+}
+
+// StateEdges is Edges for worlds where each cell has one of several states, giving radius cells beyond each side of
+// each row. west[i][d] and east[i][d] are the cells d+1 beyond the left and right edges of row startY+i
+func (b Boundary) StateEdges(world []StateArray, startY, n, radius int) (west, east [][]uint8) {
+	if b != CrossSurface {
+		return nil, nil
+	}
+	width, height := world[0].Len(), len(world)
+	west, east = make([][]uint8, n), make([][]uint8, n)
+	for i := 0; i < n; i++ {
+		west[i], east[i] = make([]uint8, radius), make([]uint8, radius)
+		for d := 0; d < radius; d++ {
+			if x, y, ok := b.Wrap(-1-d, startY+i, width, height); ok {
+				west[i][d] = world[y].Get(x)
+			}
+			if x, y, ok := b.Wrap(width+d, startY+i, width, height); ok {
+				east[i][d] = world[y].Get(x)
+			}
+		}
+	}
+	return
+}
@@ -0,0 +1,99 @@
+package util
+
+import "testing"
+
+// TestMirror checks that values beyond either end come back in as reflections, however far beyond they are
+func TestMirror(t *testing.T) {
+	tests := []struct{ value, want int }{
+		{0, 0}, {3, 3}, {-1, 0}, {-2, 1}, {-4, 3}, {-5, 3}, {-8, 0}, {-9, 0},
+		{4, 3}, {5, 2}, {7, 0}, {8, 0}, {11, 3}, {12, 3},
+	}
+	for _, test := range tests {
+		if got := Mirror(test.value, 4); got != test.want {
+			t.Errorf("Mirror(%d, 4) = %d, want %d", test.value, got, test.want)
+		}
+	}
+}
+
+// TestWrap checks cells several beyond the edges of a 4 by 3 world, where each crossing of a twisted edge mirrors
+// the other coordinate once
+func TestWrap(t *testing.T) {
+	tests := []struct {
+		boundary     Boundary
+		x, y         int
+		wantX, wantY int
+	}{
+		{Reflect, -2, -3, 1, 2},
+		{Reflect, 5, 4, 2, 1},
+		{Reflect, -5, 7, 3, 1},
+		{KleinBottle, 1, -1, 2, 2},
+		{KleinBottle, 1, -2, 2, 1},
+		{KleinBottle, 1, -4, 1, 2},
+		{KleinBottle, 1, 5, 2, 2},
+		{KleinBottle, 1, 6, 1, 0},
+		{KleinBottle, -2, 1, 2, 1},
+		{KleinBottle, -6, -1, 1, 2},
+		{CrossSurface, -2, 0, 2, 2},
+		{CrossSurface, -6, 0, 2, 0},
+		{CrossSurface, 0, 4, 3, 1},
+		{Torus, -6, 7, 2, 1},
+	}
+	for _, test := range tests {
+		x, y, ok := test.boundary.Wrap(test.x, test.y, 4, 3)
+		if !ok || x != test.wantX || y != test.wantY {
+			t.Errorf("%v Wrap(%d, %d) = %d, %d, want %d, %d", test.boundary, test.x, test.y, x, y, test.wantX, test.wantY)
+		}
+	}
+	if _, _, ok := DeadBorder.Wrap(-2, 1, 4, 3); ok {
+		t.Error("a cell beyond a dead border should be dead")
+	}
+}
+
+// TestRows checks that the rows and edges built beyond the world with a radius larger than the world match Wrap
+func TestRows(t *testing.T) {
+	const width, height, radius = 8, 3, 11
+	states := make([]StateArray, height)
+	bits := make([]BitArray, height)
+	for y := range states {
+		states[y] = NewStateArray(width, BitsForStates(256))
+		bits[y] = NewBitArray(width)
+		for x := 0; x < width; x++ {
+			states[y].Set(x, uint8(y*width+x))
+			bits[y].SetBit(x, (x+2*y)%3 == 0)
+		}
+	}
+	for b := Torus; b <= CrossSurface; b++ {
+		west, east := b.StateEdges(states, -radius, height+2*radius, radius)
+		for i := 0; i < height+2*radius; i++ {
+			y := i - radius
+			row, bitRow := b.StateRow(states, y), b.Row(bits, y)
+			for x := -radius; x < width+radius; x++ {
+				var want uint8
+				wantBit := false
+				if wx, wy, ok := b.Wrap(x, y, width, height); ok {
+					want = states[wy].Get(wx)
+					wantBit = bits[wy].GetBit(wx)
+				}
+				var got uint8
+				switch {
+				case x < 0 && b == CrossSurface:
+					got = west[i][-1-x]
+				case x >= width && b == CrossSurface:
+					got = east[i][x-width]
+				case x < 0 || x >= width:
+					if wx, _, ok := b.Wrap(x, 0, width, 1); ok {
+						got = row.Get(wx)
+					}
+				default:
+					got = row.Get(x)
+					if bitRow.GetBit(x) != wantBit {
+						t.Errorf("%v Row(%d) cell %d is %v, want %v", b, y, x, bitRow.GetBit(x), wantBit)
+					}
+				}
+				if got != want {
+					t.Errorf("%v cell %d, %d is %d, want %d", b, x, y, got, want)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package util
+
+// StateArray is a row of cells that can each be in one of up to 2^Bits states, packed into bytes.
+// With one bit per cell it has the same layout as a BitArray
+type StateArray struct {
+	Bits uint8 // bits per cell, one of 1, 2, 4 or 8
+	Data []uint8
+}
+
+// BitsForStates returns the smallest number of bits per cell, out of 1, 2, 4 and 8, that can hold the given states
+func BitsForStates(states int) uint8 {
+	bits := uint8(1)
+	for bits < 8 && 1<<bits < states {
+		bits *= 2
+	}
+	return bits
+}
+
+func NewStateArray(n int, bits uint8) StateArray {
+	return StateArray{Bits: bits, Data: make([]uint8, (n*int(bits)+7)/8)}
+}
+
+// Get returns the state of the cell at index
+func (s StateArray) Get(index int) uint8 {
+	bit := uint(index) * uint(s.Bits)
+	mask := uint8(1)<<s.Bits - 1
+	return (s.Data[bit/8] >> (bit % 8)) & mask
+}
+
+// Set changes the state of the cell at index, states that do not fit in Bits are truncated
+func (s StateArray) Set(index int, state uint8) {
+	bit := uint(index) * uint(s.Bits)
+	mask := uint8(1)<<s.Bits - 1
+	s.Data[bit/8] = s.Data[bit/8]&^(mask<<(bit%8)) | (state&mask)<<(bit%8)
+}
+
+// Len returns the number of cells in the row
+func (s StateArray) Len() int {
+	return 8 * len(s.Data) / int(s.Bits)
+}
+
+// StateToGrey maps a state to the grey level used in images. Dead is black, alive is white and the
+// dying states of Generations rules fade from white towards black
+func StateToGrey(state uint8, states int) uint8 {
+	if state == 0 || states < 2 {
+		return 0
+	}
+	return uint8(255 * (states - int(state)) / (states - 1))
+}
+
+// GreyToState maps a grey level from an image to the state with the closest grey level.
+// With only two states any grey level that is not black is alive, in the same way as BitArray.SetBitFromUint8
+func GreyToState(grey uint8, states int) uint8 {
+	if states <= 2 {
+		if grey != 0 {
+			return 1
+		}
+		return 0
+	}
+	best, bestDistance := uint8(0), 256
+	for state := 0; state < states; state++ {
+		distance := int(StateToGrey(uint8(state), states)) - int(grey)
+		if distance < 0 {
+			distance = -distance
+		}
+		if distance < bestDistance {
+			best, bestDistance = uint8(state), distance
+		}
+	}
+	return best
+}
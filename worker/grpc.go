@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/grpc"
+	"io"
+	"net"
+	pb "uk.ac.bris.cs/gameoflife/proto"
+	"uk.ac.bris.cs/gameoflife/stubs"
+)
+
+// workerServer serves the RPC methods of the worker over gRPC, converting to and from the structs of stubs
+type workerServer struct {
+	pb.UnimplementedWorkerServer
+	w *WorkerOperations
+}
+
+func (s workerServer) Hello(_ context.Context, req *pb.Hello) (*pb.Hello, error) {
+	res := new(stubs.Hello)
+	if err := s.w.Hello(pb.ToHello(req), res); err != nil {
+		return nil, err
+	}
+	return pb.FromHello(*res), nil
+}
+
+func (s workerServer) Worker(_ context.Context, req *pb.WorkerRequest) (*pb.WorkerResponse, error) {
+	res := new(stubs.WorkerResponse)
+	if err := s.w.Worker(pb.ToWorkerRequest(req), res); err != nil {
+		return nil, err
+	}
+	return pb.FromWorkerResponse(*res), nil
+}
+
+func (s workerServer) KillWorker(context.Context, *pb.Empty) (*pb.Empty, error) {
+	return &pb.Empty{}, s.w.KillWorker(struct{}{}, nil)
+}
+
+// StreamStrips answers each request sent over the stream with the next state of its strip, until the broker ends
+// the stream. A request that fails ends the stream with its error
+func (s workerServer) StreamStrips(stream pb.Worker_StreamStripsServer) error {
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		res, err := s.Worker(stream.Context(), req)
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(res); err != nil {
+			return err
+		}
+	}
+}
+
+// serveGRPC serves the worker over gRPC on addr, alongside net/rpc
+func (w *WorkerOperations) serveGRPC(addr string) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println("#GRPC ON", addr)
+	if err := newGRPCServer(w).Serve(listener); err != nil {
+		fmt.Println(err)
+	}
+}
+
+// newGRPCServer returns a gRPC server of the worker
+func newGRPCServer(w *WorkerOperations) *grpc.Server {
+	server := pb.NewServer()
+	pb.RegisterWorkerServer(server, workerServer{w: w})
+	return server
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"net"
+	"testing"
+	pb "uk.ac.bris.cs/gameoflife/proto"
+	"uk.ac.bris.cs/gameoflife/rule"
+	"uk.ac.bris.cs/gameoflife/stubs"
+	"uk.ac.bris.cs/gameoflife/util"
+)
+
+// grpcWorker serves w over gRPC and returns a client of it, and a function that stops both
+func grpcWorker(t *testing.T, w *WorkerOperations) (*pb.Client, func()) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := newGRPCServer(w)
+	go server.Serve(listener)
+	client, err := pb.Dial(listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client, func() {
+		_ = client.Close()
+		server.Stop()
+	}
+}
+
+// TestStreamStrips checks that dense, sparse and state turns made over one StreamStrips stream match the reference,
+// and that a request that fails ends the stream without stopping the next request from opening another
+func TestStreamStrips(t *testing.T) {
+	w := &WorkerOperations{threads: 2}
+	client, stop := grpcWorker(t, w)
+	defer stop()
+	if hello, err := stubs.CallHello(client, stubs.WorkerHello, stubs.Legacy); err != nil || hello.Version != 1 {
+		t.Fatalf("Hello over gRPC agreed on %+v, %v, want version 1 with a legacy broker", hello, err)
+	}
+
+	dense := readWorld("../images/64x64.pgm")
+	sparse := readWorld("../images/64x64.pgm")
+	activity := util.NewActivity(sparse, util.Reflect)
+	for turn := 1; turn <= 10; turn++ {
+		s := withOverlap(dense, util.Reflect)
+		request := stubs.WorkerRequest{Scale: len(dense), WorldWidth: s.width, InPart: s.rows, Boundary: util.Reflect, West: s.west, East: s.east}
+		response := new(stubs.WorkerResponse)
+		if err := client.Call(stubs.Worker, request, response); err != nil {
+			t.Fatal(err)
+		}
+		expected := referenceStep(dense, util.Reflect)
+		assertEqualWorld(t, response.OutPart, expected, turn)
+		dense = expected
+
+		active := activity.Active()
+		tiles := activity.StripTiles(active, 0, len(sparse))
+		request = stubs.WorkerRequest{Scale: len(sparse), WorldWidth: s.width, Sparse: true, ActiveTiles: tiles,
+			Halos: util.Halos(sparse, util.Reflect, tiles, 0, len(sparse))}
+		response = new(stubs.WorkerResponse)
+		if err := client.Call(stubs.Worker, request, response); err != nil {
+			t.Fatal(err)
+		}
+		activity.Advance(sparse, active, response.Tiles)
+		assertEqualWorld(t, sparse, expected, turn)
+	}
+
+	if err := client.Call(stubs.Worker, stubs.WorkerRequest{Rule: "B3/S23/bogus"}, new(stubs.WorkerResponse)); err == nil {
+		t.Error("a request with a rule that does not parse should fail")
+	}
+	r, err := rule.Parse("B2/S/C3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	world := toStates(readWorld("../images/16x16.pgm"), r.States())
+	rows := []util.StateArray{util.Torus.StateRow(world, -1)}
+	rows = append(rows, world...)
+	rows = append(rows, util.Torus.StateRow(world, len(world)))
+	request := stubs.WorkerRequest{Scale: len(world), WorldWidth: world[0].Len(), Rule: "B2/S/C3", InStates: rows}
+	response := new(stubs.WorkerResponse)
+	if err := client.Call(stubs.Worker, request, response); err != nil {
+		t.Fatalf("the request after one that failed did not open another stream: %v", err)
+	}
+	expected := referenceStateStep(world, util.Torus, r)
+	for y := range expected {
+		for x := 0; x < expected[y].Len(); x++ {
+			if response.OutStates[y].Get(x) != expected[y].Get(x) {
+				t.Fatalf("cell (%d, %d) is %d, want %d", x, y, response.OutStates[y].Get(x), expected[y].Get(x))
+			}
+		}
+	}
+
+	if err := client.Call(stubs.KillWorker, struct{}{}, new(struct{})); err != nil || !w.kill {
+		t.Errorf("KillWorker over gRPC left kill %v, %v", w.kill, err)
+	}
+}
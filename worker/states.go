@@ -0,0 +1,86 @@
+package main
+
+import (
+	"uk.ac.bris.cs/gameoflife/rule"
+	"uk.ac.bris.cs/gameoflife/util"
+)
+
+// stateStrip is a strip where each cell has one of several states, it is the rule.Grid the rules read from
+type stateStrip struct {
+	rows       []util.StateArray
+	width      int
+	radius     int // number of ghost rows above and below the strip, and of columns read beyond each side
+	boundary   util.Boundary
+	west, east [][]uint8 // the cells beyond the left and right of each row, only used by the cross-surface
+}
+
+// slice returns the rows start (inclusive) to end (exclusive) of a strip
+func (s stateStrip) slice(start, end int) stateStrip {
+	sliced := s
+	sliced.rows = s.rows[start:end]
+	if s.west != nil {
+		sliced.west, sliced.east = s.west[start:end], s.east[start:end]
+	}
+	return sliced
+}
+
+// State returns the state of the cell x, y of the strip, following the boundary beyond the left and right edges
+func (s stateStrip) State(x, y int) uint8 {
+	if x >= 0 && x < s.width {
+		return s.rows[y].Get(x)
+	}
+	switch s.boundary {
+	case util.DeadBorder:
+		return 0
+	case util.Reflect:
+		return s.rows[y].Get(util.Mirror(x, s.width))
+	case util.CrossSurface:
+		if x < 0 {
+			return s.west[y][-1-x]
+		}
+		return s.east[y][x-s.width]
+	default: // the torus and klein bottle join the left and right edges without a twist
+		return s.rows[y].Get(transformY(x, s.width))
+	}
+}
+
+// stateWorker applies a rule to every row of a strip, skipping the overlaps. Totalistic rules count neighbours from
+// a summed-area table of the strip, so the time per cell does not grow with the size of the neighbourhood
+func stateWorker(scale int, s stateStrip, r rule.Rule, outChannel chan []util.StateArray) {
+	bits := util.BitsForStates(r.States())
+	outPart := make([]util.StateArray, scale)
+	totalistic, ok := r.(rule.Totalistic)
+	var table *rule.Table
+	if ok && scale > 0 {
+		table = rule.NewTable(s, -s.radius, 0, s.width+2*s.radius, len(s.rows))
+	}
+	for y := range outPart {
+		outPart[y] = util.NewStateArray(s.width, bits)
+		for x := 0; x < s.width; x++ {
+			if ok {
+				outPart[y].Set(x, totalistic.NextCount(s.State(x, y+s.radius), totalistic.Count(table, x, y+s.radius)))
+			} else {
+				outPart[y].Set(x, r.Next(s, x, y+s.radius))
+			}
+		}
+	}
+	outChannel <- outPart
+}
+
+// stateDistributor splits a strip between goroutines in the same way as subDistributor, for rules other than Life
+func stateDistributor(scale int, s stateStrip, r rule.Rule, threads int) []util.StateArray {
+	outPart := make([]util.StateArray, 0)
+	subScale := threadScale(scale, threads)
+	workerChannels := make([]chan []util.StateArray, threads)
+	startY := 0
+	for i := range workerChannels {
+		workerChannels[i] = make(chan []util.StateArray)
+		go stateWorker(subScale[i], s.slice(startY, startY+subScale[i]+2*s.radius), r, workerChannels[i])
+		startY += subScale[i]
+	}
+
+	for _, ch := range workerChannels {
+		outPart = append(outPart, <-ch...)
+	}
+	return outPart
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"testing"
+	"uk.ac.bris.cs/gameoflife/rule"
+	"uk.ac.bris.cs/gameoflife/stubs"
+	"uk.ac.bris.cs/gameoflife/util"
+)
+
+// boundaryGrid is a whole world that wraps every cell with the boundary, so rules can be applied to it directly
+type boundaryGrid struct {
+	world    []util.StateArray
+	boundary util.Boundary
+}
+
+func (g boundaryGrid) State(x, y int) uint8 {
+	if wx, wy, ok := g.boundary.Wrap(x, y, g.world[0].Len(), len(g.world)); ok {
+		return g.world[wy].Get(wx)
+	}
+	return 0
+}
+
+// toStates converts a binary world into a world of states
+func toStates(world []util.BitArray, states int) []util.StateArray {
+	converted := make([]util.StateArray, len(world))
+	for y, row := range world {
+		converted[y] = util.NewStateArray(row.Len(), util.BitsForStates(states))
+		for x := 0; x < row.Len(); x++ {
+			if row.GetBit(x) {
+				converted[y].Set(x, 1)
+			}
+		}
+	}
+	return converted
+}
+
+// stateStep computes the next turn of a world in a single request, with the ghost rows the rule needs
+func stateStep(world []util.StateArray, boundary util.Boundary, r rule.Rule) []util.StateArray {
+	radius := r.Radius()
+	var rows []util.StateArray
+	for y := -radius; y < len(world)+radius; y++ {
+		rows = append(rows, boundary.StateRow(world, y))
+	}
+	west, east := boundary.StateEdges(world, -radius, len(rows), radius)
+	s := stateStrip{rows: rows, width: world[0].Len(), radius: radius, boundary: boundary, west: west, east: east}
+	return stateDistributor(len(world), s, r, stubs.Threads)
+}
+
+// referenceStateStep computes the next turn of a world by applying the rule to every cell of the whole world
+func referenceStateStep(world []util.StateArray, boundary util.Boundary, r rule.Rule) []util.StateArray {
+	next := make([]util.StateArray, len(world))
+	for y := range world {
+		next[y] = util.NewStateArray(world[y].Len(), world[y].Bits)
+		for x := 0; x < world[y].Len(); x++ {
+			next[y].Set(x, r.Next(boundaryGrid{world, boundary}, x, y))
+		}
+	}
+	return next
+}
+
+// TestStateRules checks strips against applying the rule to the whole world, for each boundary and neighbourhood,
+// which also checks the summed-area tables of the workers against reading every neighbour
+func TestStateRules(t *testing.T) {
+	rules := []string{"bosco", "R3,C4,M0,S4..9,B5..7,NN", "R2,C0,M0,S3..8,B4..6,NH", "B2/S345/C4", "B2/S34H",
+		"B2/S13/C3V", "B3/S23/NW1020102220200022202010201", "B2-a/S12", "tlife", "wireworld"}
+	for b := util.Torus; b <= util.CrossSurface; b++ {
+		for _, name := range rules {
+			boundary := b
+			r, err := rule.Parse(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Run(boundary.String()+"/"+name, func(t *testing.T) {
+				expected := toStates(readWorld("../images/64x64.pgm")[:40], r.States())
+				given := expected
+				for turn := 1; turn <= 20; turn++ {
+					expected = referenceStateStep(expected, boundary, r)
+					given = stateStep(given, boundary, r)
+					for y := range expected {
+						for x := 0; x < expected[y].Len(); x++ {
+							if given[y].Get(x) != expected[y].Get(x) {
+								t.Fatalf("turn %d: cell (%d, %d) differs", turn, x, y)
+							}
+						}
+					}
+				}
+			})
+		}
+	}
+}
+
+// TestRadiusWiderThanWorld checks a rule that reads further than the world is wide or tall, so that cells are
+// wrapped around more than once, against applying the rule to the whole world
+func TestRadiusWiderThanWorld(t *testing.T) {
+	r, err := rule.Parse("R12,C0,M1,S40..150,B60..120,NM")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for b := util.Torus; b <= util.CrossSurface; b++ {
+		boundary := b
+		t.Run(boundary.String(), func(t *testing.T) {
+			expected := toStates(readWorld("../images/16x16.pgm")[:8], r.States())
+			for y := range expected {
+				expected[y] = util.StateArray{Bits: expected[y].Bits, Data: expected[y].Data[:1]}
+			}
+			given := expected
+			for turn := 1; turn <= 5; turn++ {
+				expected = referenceStateStep(expected, boundary, r)
+				given = stateStep(given, boundary, r)
+				for y := range expected {
+					for x := 0; x < expected[y].Len(); x++ {
+						if given[y].Get(x) != expected[y].Get(x) {
+							t.Fatalf("turn %d: cell (%d, %d) differs", turn, x, y)
+						}
+					}
+				}
+			}
+		})
+	}
+}
@@ -5,13 +5,16 @@ import (
 	"fmt"
 	"net"
 	"net/rpc"
+	"runtime"
 	"time"
+	"uk.ac.bris.cs/gameoflife/rule"
 	"uk.ac.bris.cs/gameoflife/stubs"
 	"uk.ac.bris.cs/gameoflife/util"
 )
 
 type WorkerOperations struct {
-	kill bool
+	kill    bool
+	threads int // number of goroutines a strip is split between when the request does not ask for a number
 }
 
 // makeWorld is a way to create empty worlds (or parts of worlds)
@@ -23,12 +26,10 @@ func makeWorld(height, width int) []util.BitArray {
 	return world
 }
 
-// transformY deals with the wrap around of Y, i.e. negative values or values over the height
+// transformY deals with the wrap around of Y, i.e. negative values or values over the height, however far outside
+// the world they are
 func transformY(value, height int) int {
-	if value == -1 {
-		return height - 1
-	}
-	return (value + height) % height
+	return ((value % height) + height) % height
 }
 
 // threadScale Creates an array of length threads with the scale for each thread
@@ -47,8 +48,46 @@ func threadScale(height, threads int) []int {
 	return scale
 }
 
+// strip is part of the world with one extra row above and below it, along with how its columns wrap around
+type strip struct {
+	rows       []util.BitArray
+	width      int
+	boundary   util.Boundary
+	west, east []bool // the cells beyond the left and right of each row, only used by the cross-surface
+}
+
+// slice returns the rows start (inclusive) to end (exclusive) of a strip
+func (s strip) slice(start, end int) strip {
+	sliced := s
+	sliced.rows = s.rows[start:end]
+	if s.west != nil {
+		sliced.west, sliced.east = s.west[start:end], s.east[start:end]
+	}
+	return sliced
+}
+
+// cell returns whether the cell x, y of the strip is alive, following the boundary beyond the left and right edges
+func (s strip) cell(x, y int) bool {
+	if x >= 0 && x < s.width {
+		return s.rows[y].GetBit(x)
+	}
+	switch s.boundary {
+	case util.DeadBorder:
+		return false
+	case util.Reflect:
+		return s.rows[y].GetBit(util.Mirror(x, s.width))
+	case util.CrossSurface:
+		if x < 0 {
+			return s.west[y]
+		}
+		return s.east[y]
+	default: // the torus and klein bottle join the left and right edges without a twist
+		return s.rows[y].GetBit(transformY(x, s.width))
+	}
+}
+
 // countLiveNeighbors calculates the number of live neighbors around a given cell.
-func countLiveNeighbors(x, y, w int, part []util.BitArray) int {
+func countLiveNeighbors(x, y int, s strip) int {
 	liveNeighbors := 0
 	directions := []struct{ dx, dy int }{
 		{-1, -1}, {0, -1}, {1, -1},
@@ -57,41 +96,93 @@ func countLiveNeighbors(x, y, w int, part []util.BitArray) int {
 	}
 
 	for _, dir := range directions {
-		nx := transformY(x+dir.dx, w)
-		ny := y + dir.dy
-		if part[ny].GetBit(nx) == stubs.Alive {
+		if s.cell(x+dir.dx, y+dir.dy) == stubs.Alive {
 			liveNeighbors++
 		}
 	}
 	return liveNeighbors
 }
 
-// worker is a routine to deal with smaller parts of the world, takes part []util.BitArray, which is part of the world with height + 2
-func worker(scale, worldWidth int, part []util.BitArray, outChannel chan []util.BitArray) {
-	outPart := makeWorld(scale, worldWidth)
-	for y := 1; y < len(part)-1; y++ { // row by row, skipping the overlaps
-		for x := 0; x < worldWidth; x++ { // each cell in row
-			liveNeighbors := countLiveNeighbors(x, y, worldWidth, part)
-			if part[y].GetBit(x) == stubs.Alive { //apply GoL rules
-				if liveNeighbors >= 2 && liveNeighbors <= 3 { //less than 2 live neighbours or more than 3
-					outPart[(y-1)].SetBit(x, stubs.Alive)
+// nextCell applies the GoL rules to the cell at x, y of the strip and returns whether it is alive on the next turn
+func nextCell(x, y int, s strip) bool {
+	liveNeighbors := countLiveNeighbors(x, y, s)
+	if s.rows[y].GetBit(x) == stubs.Alive { //apply GoL rules
+		return liveNeighbors >= 2 && liveNeighbors <= 3 //less than 2 live neighbours or more than 3
+	}
+	return liveNeighbors == 3 //any dead cell with exactly three live neighbours becomes alive
+}
+
+// worker is a routine to deal with smaller parts of the world, takes a strip, which is part of the world with height + 2
+func worker(scale int, s strip, outChannel chan []util.BitArray) {
+	outPart := makeWorld(scale, s.width)
+	for y := 1; y < len(s.rows)-1; y++ { // row by row, skipping the overlaps
+		for x := 0; x < s.width; x++ { // each cell in row
+			if nextCell(x, y, s) {
+				outPart[(y-1)].SetBit(x, stubs.Alive)
+			}
+		}
+	}
+	outChannel <- outPart
+}
+
+// sparseWorker recomputes the given tiles of a strip starting at row startY from the cells around them in halos,
+// and sends back only the tiles that changed
+func sparseWorker(startY, scale, width int, tiles []util.TileIndex, halos []util.Tile, outChannel chan []util.Tile) {
+	changed := make([]util.Tile, 0)
+	for i, tile := range tiles {
+		// the tile is clipped to the strip, as neighbouring strips are given to other workers
+		x0, x1, y0, y1 := util.TileBounds(tile, width, startY, startY+scale)
+		// the halo holds every cell that the tile reads, so nothing beyond its edges is needed
+		halo := halos[i]
+		s := strip{rows: halo.Rows, width: halo.Rows[0].Len(), boundary: util.DeadBorder}
+		rows := makeWorld(y1-y0, x1-x0)
+		different := false
+		for y := y0; y < y1; y++ {
+			haloY := y - halo.Y
+			for x := x0; x < x1; x++ {
+				haloX := x - halo.X
+				alive := nextCell(haloX, haloY, s)
+				if alive {
+					rows[y-y0].SetBit(x-x0, stubs.Alive)
 				}
-			} else { //dead
-				if liveNeighbors == 3 { //any dead cell with exactly three live neighbours becomes alive
-					outPart[(y-1)].SetBit(x, stubs.Alive)
+				if alive != s.rows[haloY].GetBit(haloX) {
+					different = true
 				}
 			}
 		}
+		if different {
+			changed = append(changed, util.Tile{X: x0, Y: y0, Rows: rows})
+		}
 	}
-	outChannel <- outPart
+	outChannel <- changed
+}
+
+// sparseDistributor shares the active tiles of a strip between goroutines and returns the tiles that changed
+func sparseDistributor(startY, scale, width int, tiles []util.TileIndex, halos []util.Tile, threads int) []util.Tile {
+	changed := make([]util.Tile, 0)
+	tileScale := threadScale(len(tiles), threads)
+	workerChannels := make([]chan []util.Tile, threads)
+	start := 0
+	for i := range workerChannels {
+		end := start + tileScale[i]
+		workerChannels[i] = make(chan []util.Tile)
+		go sparseWorker(startY, scale, width, tiles[start:end], halos[start:end], workerChannels[i])
+		start = end
+	}
+
+	for _, ch := range workerChannels {
+		changed = append(changed, <-ch...)
+	}
+	return changed
 }
 
-// subDistributor is a routine to deal with smaller parts of the world, takes part []util.BitArray, which is part of the world with height + 2
-func subDistributor(scale, worldWidth int, part []util.BitArray) []util.BitArray {
+// subDistributor is a routine to deal with smaller parts of the world, takes a strip, which is part of the world with height + 2,
+// and splits it between threads goroutines
+func subDistributor(scale int, s strip, threads int) []util.BitArray {
 	outPart := make([]util.BitArray, 0)
-	subScale := threadScale(scale, stubs.Threads)
-	workerChannels := make([]chan []util.BitArray, stubs.Threads) // rows
-	for i := 0; i < stubs.Threads; i++ {
+	subScale := threadScale(scale, threads)
+	workerChannels := make([]chan []util.BitArray, threads) // rows
+	for i := 0; i < threads; i++ {
 		workerChannels[i] = make(chan []util.BitArray) //2d slice  //columns
 	}
 
@@ -101,8 +192,8 @@ func subDistributor(scale, worldWidth int, part []util.BitArray) []util.BitArray
 	for i := range workerChannels {
 		endY = startY + subScale[i] + 1
 		// cuts up world into parts needed for each thread
-		inPart := part[startY : endY+1]
-		go worker(subScale[i], worldWidth, inPart, workerChannels[i])
+		inPart := s.slice(startY, endY+1)
+		go worker(subScale[i], inPart, workerChannels[i])
 		startY += subScale[i]
 	}
 
@@ -114,12 +205,82 @@ func subDistributor(scale, worldWidth int, part []util.BitArray) []util.BitArray
 	return outPart
 }
 
+// threadsPerCPU is the most goroutines a worker splits a strip between for each of its CPUs, whatever it is asked for
+const threadsPerCPU = 4
+
+// threadsFor returns the number of goroutines to split the strip of request between, the number the broker asked
+// for or otherwise own, but no more than there are rows in the strip or threadsPerCPU for each CPU
+func threadsFor(request stubs.WorkerRequest, own int) int {
+	threads := own
+	if request.Threads > 0 {
+		threads = request.Threads
+	}
+	if limit := threadsPerCPU * runtime.NumCPU(); threads > limit {
+		threads = limit
+	}
+	if threads > request.Scale {
+		threads = request.Scale
+	}
+	if threads < 1 {
+		threads = 1
+	}
+	return threads
+}
+
 // Worker is an RPC call that takes performs the GOL logic for part of the world
 func (w *WorkerOperations) Worker(request stubs.WorkerRequest, response *stubs.WorkerResponse) (err error) {
-	response.OutPart = subDistributor(request.Scale, request.WorldWidth, request.InPart)
+	threads := threadsFor(request, w.threads)
+	if !rule.IsLife(request.Rule) {
+		r, err := rule.Parse(request.Rule)
+		if err != nil {
+			return err
+		}
+		s := stateStrip{
+			rows:     request.InStates,
+			width:    request.WorldWidth,
+			radius:   r.Radius(),
+			boundary: request.Boundary,
+			west:     request.WestStates,
+			east:     request.EastStates,
+		}
+		response.OutStates = stateDistributor(request.Scale, s, r, threads)
+		return nil
+	}
+	if request.Sparse {
+		if len(request.Halos) != len(request.ActiveTiles) {
+			return fmt.Errorf("%d halos were sent for %d active tiles", len(request.Halos), len(request.ActiveTiles))
+		}
+		response.Tiles = sparseDistributor(request.StartY, request.Scale, request.WorldWidth, request.ActiveTiles, request.Halos, threads)
+		return
+	}
+	s := strip{
+		rows:     request.InPart,
+		width:    request.WorldWidth,
+		boundary: request.Boundary,
+		west:     request.West,
+		east:     request.East,
+	}
+	response.OutPart = subDistributor(request.Scale, s, threads)
 	return
 }
 
+// Hello is an RPC that answers the broker with what the worker supports, refusing a broker it cannot talk to
+func (w *WorkerOperations) Hello(request stubs.Hello, response *stubs.Hello) error {
+	hello := stubs.Hello{
+		Version:    stubs.ProtocolVersion,
+		MinVersion: stubs.MinProtocolVersion,
+		Rules:      rule.Families,
+		Encodings:  []string{stubs.EncodingBits, stubs.EncodingStates},
+		Features:   stubs.WorkerFeatures,
+	}
+	if _, err := stubs.Negotiate(hello, request); err != nil {
+		fmt.Println("#REFUSED BROKER:", err)
+		return err
+	}
+	*response = hello
+	return nil
+}
+
 // KillWorker is an RPC that stops the subDistributor running, it will only be called when Worker is not due to the nature of broker
 func (w *WorkerOperations) KillWorker(_ struct{}, _ *struct{}) error {
 	w.kill = true
@@ -129,11 +290,19 @@ func (w *WorkerOperations) KillWorker(_ struct{}, _ *struct{}) error {
 // main initialises the server & creates a way of killing through w.kill
 func main() {
 	pAddr := flag.String("port", "8030", "Port to listen on")
+	threads := flag.Int("threads", runtime.NumCPU(), "Number of goroutines to split each strip between, unless the broker asks for a number")
+	grpcAddr := flag.String("grpc", "", "Address to serve the worker over gRPC on as well as net/rpc, such as :8041, empty for none")
 	flag.Parse()
-	w := new(WorkerOperations)
+	if *threads < 1 {
+		*threads = 1
+	}
+	w := &WorkerOperations{threads: *threads}
 	if err := rpc.Register(w); err != nil {
 		fmt.Println(err)
 	}
+	if *grpcAddr != "" {
+		go w.serveGRPC(*grpcAddr)
+	}
 	listener, err := net.Listen("tcp", ":"+*pAddr)
 	if err != nil {
 		fmt.Println(err)
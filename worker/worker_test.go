@@ -0,0 +1,202 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+	"uk.ac.bris.cs/gameoflife/pnm"
+	"uk.ac.bris.cs/gameoflife/stubs"
+	"uk.ac.bris.cs/gameoflife/util"
+)
+
+// stableTurns is the number of turns after which images/512x512.pgm has settled into still lifes and oscillators
+const stableTurns = 5000
+
+// readWorld loads a pgm image as a world
+func readWorld(path string) []util.BitArray {
+	img, err := pnm.ReadFile(path)
+	util.Check(err)
+	world := makeWorld(img.Height, img.Width)
+	for y := range world {
+		for x := 0; x < img.Width; x++ {
+			world[y].SetBitFromUint8(x, img.At(x, y))
+		}
+	}
+	return world
+}
+
+// withOverlap returns the world as a single strip with the rows beyond the top and bottom edges
+func withOverlap(world []util.BitArray, boundary util.Boundary) strip {
+	rows := []util.BitArray{boundary.Row(world, -1)}
+	rows = append(rows, world...)
+	rows = append(rows, boundary.Row(world, len(world)))
+	west, east := boundary.Edges(world, -1, len(rows))
+	return strip{rows: rows, width: world[0].Len(), boundary: boundary, west: west, east: east}
+}
+
+// denseStep computes the next turn of a world in a single dense request
+func denseStep(world []util.BitArray, boundary util.Boundary) []util.BitArray {
+	return subDistributor(len(world), withOverlap(world, boundary), stubs.Threads)
+}
+
+// sparseStep computes the next turn of a world in place, recomputing only the active tiles
+func sparseStep(world []util.BitArray, boundary util.Boundary, activity *util.Activity) {
+	active := activity.Active()
+	tiles := activity.StripTiles(active, 0, len(world))
+	halos := util.Halos(world, boundary, tiles, 0, len(world))
+	activity.Advance(world, active, sparseDistributor(0, len(world), world[0].Len(), tiles, halos, stubs.Threads))
+}
+
+// referenceStep computes the next turn of a world by wrapping every neighbour with the boundary
+func referenceStep(world []util.BitArray, boundary util.Boundary) []util.BitArray {
+	width, height := world[0].Len(), len(world)
+	next := makeWorld(height, width)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			neighbours := 0
+			for dy := -1; dy <= 1; dy++ {
+				for dx := -1; dx <= 1; dx++ {
+					if dx == 0 && dy == 0 {
+						continue
+					}
+					if nx, ny, ok := boundary.Wrap(x+dx, y+dy, width, height); ok && world[ny].GetBit(nx) {
+						neighbours++
+					}
+				}
+			}
+			next[y].SetBit(x, neighbours == 3 || (neighbours == 2 && world[y].GetBit(x)))
+		}
+	}
+	return next
+}
+
+func assertEqualWorld(t *testing.T, given, expected []util.BitArray, turn int) {
+	for y := range expected {
+		for x := 0; x < expected[y].Len(); x++ {
+			if given[y].GetBit(x) != expected[y].GetBit(x) {
+				t.Fatalf("turn %d: cell (%d, %d) differs", turn, x, y)
+			}
+		}
+	}
+}
+
+// TestSparse checks that sparse turns produce the same worlds as dense turns, and the expected world after 100 turns
+func TestSparse(t *testing.T) {
+	for _, size := range []int{16, 64, 512} {
+		t.Run(fmt.Sprintf("%dx%d", size, size), func(t *testing.T) {
+			dense := readWorld(fmt.Sprintf("../images/%dx%d.pgm", size, size))
+			sparse := readWorld(fmt.Sprintf("../images/%dx%d.pgm", size, size))
+			activity := util.NewActivity(sparse, util.Torus)
+			for turn := 1; turn <= 100; turn++ {
+				dense = denseStep(dense, util.Torus)
+				sparseStep(sparse, util.Torus, activity)
+				assertEqualWorld(t, sparse, dense, turn)
+			}
+			expected := readWorld(fmt.Sprintf("../check/images/%dx%dx100.pgm", size, size))
+			assertEqualWorld(t, sparse, expected, 100)
+		})
+	}
+}
+
+// TestBoundaries checks dense and sparse turns against the reference for each boundary, on a world that is not square
+func TestBoundaries(t *testing.T) {
+	for b := util.Torus; b <= util.CrossSurface; b++ {
+		boundary := b
+		t.Run(boundary.String(), func(t *testing.T) {
+			source := readWorld("../images/64x64.pgm")
+			expected := source[:40]
+			dense := makeWorld(40, 64)
+			sparse := makeWorld(40, 64)
+			for y := range expected {
+				copy(dense[y], expected[y])
+				copy(sparse[y], expected[y])
+			}
+			activity := util.NewActivity(sparse, boundary)
+			for turn := 1; turn <= 100; turn++ {
+				expected = referenceStep(expected, boundary)
+				dense = denseStep(dense, boundary)
+				sparseStep(sparse, boundary, activity)
+				assertEqualWorld(t, dense, expected, turn)
+				assertEqualWorld(t, sparse, expected, turn)
+			}
+		})
+	}
+}
+
+// TestThreads checks that a strip gives the same result however many goroutines it is split between, including more
+// goroutines than rows
+func TestThreads(t *testing.T) {
+	world := readWorld("../images/16x16.pgm")
+	expected := referenceStep(world, util.Torus)
+	for _, n := range []int{1, 3, 16, 64} {
+		threads := n
+		t.Run(fmt.Sprint(threads), func(t *testing.T) {
+			assertEqualWorld(t, subDistributor(len(world), withOverlap(world, util.Torus), threads), expected, 1)
+			sparse := makeWorld(len(world), world[0].Len())
+			for y := range world {
+				copy(sparse[y], world[y])
+			}
+			activity := util.NewActivity(sparse, util.Torus)
+			active := activity.Active()
+			tiles := activity.StripTiles(active, 0, len(world))
+			halos := util.Halos(world, util.Torus, tiles, 0, len(world))
+			activity.Advance(sparse, active, sparseDistributor(0, len(world), world[0].Len(), tiles, halos, threads))
+			assertEqualWorld(t, sparse, expected, 1)
+		})
+	}
+}
+
+// TestThreadsFor checks that the goroutines asked for by the broker are kept to the rows of the strip and a few for
+// each CPU, and that the worker's own number is used when none are asked for
+func TestThreadsFor(t *testing.T) {
+	limit := threadsPerCPU * runtime.NumCPU()
+	tests := []struct {
+		threads, scale, own, want int
+	}{
+		{0, 100, 3, 3},
+		{2, 100, 3, 2},
+		{2, 1, 3, 1},
+		{1000000000, 1 << 20, 3, limit},
+		{-1, 100, 0, 1},
+		{4, 0, 3, 1},
+	}
+	for _, test := range tests {
+		if got := threadsFor(stubs.WorkerRequest{Threads: test.threads, Scale: test.scale}, test.own); got != test.want {
+			t.Errorf("%d threads asked for a strip of %d rows with %d of its own gave %d, want %d", test.threads, test.scale, test.own, got, test.want)
+		}
+	}
+}
+
+// TestDeadBorder checks that a blinker on the top edge of a dead border loses the cell that would be beyond it
+func TestDeadBorder(t *testing.T) {
+	world := makeWorld(16, 16)
+	for x := 4; x <= 6; x++ {
+		world[0].SetBit(x, stubs.Alive)
+	}
+	next := denseStep(world, util.DeadBorder)
+	expected := makeWorld(16, 16)
+	expected[0].SetBit(5, stubs.Alive)
+	expected[1].SetBit(5, stubs.Alive)
+	assertEqualWorld(t, next, expected, 1)
+}
+
+// BenchmarkStable compares dense and sparse turns on images/512x512.pgm once it has stabilised
+func BenchmarkStable(b *testing.B) {
+	world := readWorld("../images/512x512.pgm")
+	activity := util.NewActivity(world, util.Torus)
+	for turn := 0; turn < stableTurns; turn++ {
+		sparseStep(world, util.Torus, activity)
+	}
+
+	b.Run("dense", func(b *testing.B) {
+		current := world
+		for i := 0; i < b.N; i++ {
+			current = denseStep(current, util.Torus)
+		}
+	})
+	b.Run("sparse", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			sparseStep(world, util.Torus, activity)
+		}
+	})
+}